@@ -0,0 +1,478 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"flart/internal/commands"
+	"flart/internal/config"
+	"flart/internal/utils"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Exit codes returned by the CLI
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// commandFunc runs a subcommand with its arguments
+type commandFunc func(args []string) error
+
+// cliCommand is a subcommand of the flart CLI.
+// setup defines the command flags on fs and returns the function running the command.
+type cliCommand struct {
+	name     string
+	args     string
+	summary  string
+	setup    func(fs *flag.FlagSet) commandFunc
+	complete completion
+	// generates is set for commands that write project files, which accept --dry-run
+	generates bool
+}
+
+// usageError reports invalid command line usage and exits with exitUsage
+type usageError struct {
+	command string
+	msg     string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usageErrorf creates a usage error for command, or for the CLI itself when command is empty
+func usageErrorf(command, format string, args ...any) error {
+	return &usageError{command: command, msg: fmt.Sprintf(format, args...)}
+}
+
+// commandTable lists every subcommand in the order shown by the help
+func commandTable() []cliCommand {
+	return []cliCommand{
+		{cmdMakeModel, "<Name>... [flags]", "Create models with Equatable or Freezed and their tests", handleMakeModel, completeNone, true},
+		{cmdMakeScreen, "<Name>... [flags]", "Create screens with a bloc or cubit, optionally as a form, list or tabs", handleMakeScreen, completeFields, true},
+		{cmdMakeUseCase, "<Name>... --repo <Repository> [flags]", "Create use cases backed by a repository", handleMakeUseCase, completeNone, true},
+		{cmdMakeWidget, "<Name>... [flags]", "Create widgets with widget and golden tests", handleMakeWidget, completeNone, true},
+		{cmdMakeDataSrc, "<Name>... [flags]", "Create remote and local data sources", handleMakeDataSource, completeNone, true},
+		{cmdMakeFlavors, "[flavor,flavor,...]", "Create flavor entrypoints and a typed AppConfig", handleMakeFlavors, completeNone, true},
+		{cmdDestroyScreen, "<Name>", "Delete a screen and remove its export, route and registration", handleDestroyScreen, completeScreens, true},
+		{cmdDestroyModel, "<Name>", "Delete a model with its generated parts and test", handleDestroyModel, completeModels, true},
+		{cmdRenameScreen, "<Name> <NewName>", "Rename a screen and update its classes, imports and references", handleRenameScreen, completeScreens, true},
+		{cmdRenameModel, "<Name> <NewName>", "Rename a model and update its class, imports and references", handleRenameModel, completeModels, true},
+		{cmdApply, "[flart.yaml]", "Create every artifact described in a manifest", handleApply, completeFiles, true},
+		{cmdGenAssets, "[flags]", "Generate typed asset constants from pubspec.yaml", handleGenAssets, completeNone, true},
+		{cmdGenTheme, "<tokens.json>", "Generate ThemeData from a design token file", handleGenTheme, completeFiles, true},
+		{cmdL10nAdd, "<key> <value>", "Add a localized string to every ARB file", handleL10nAdd, completeNone, true},
+		{cmdL10nCheck, "", "Report keys missing from any ARB file", handleL10nCheck, completeNone, false},
+		{cmdPackInstall, "<dir|archive> [flags]", "Install a template pack from a directory or a .zip, .tar.gz or .tgz archive", handlePackInstall, completeFiles, true},
+		{cmdPackList, "", "List the built-in and installed template packs", handlePackList, completeNone, false},
+		{cmdUndo, "[flags]", "Revert the most recent generation", handleUndo, completeNone, true},
+		{cmdHistory, "", "List past generations", handleHistory, completeNone, false},
+		{cmdBuildRunnerCL, "", "Run build_runner once", handleBuildRunner, completeNone, false},
+		{cmdWatchRunnerCL, "", "Run build_runner in watch mode", handleWatchRunner, completeNone, false},
+		{cmdCompletion, "bash|zsh|fish", "Print the shell completion script", handleCompletion, completeShells, false},
+	}
+}
+
+// commandGroups are the command prefixes that can also be written as a separate word,
+// e.g. "pack install" for pack:install
+var commandGroups = []string{"pack"}
+
+// groupSubcommands returns the subcommands of a group, e.g. install and list for pack
+func groupSubcommands(group string) []string {
+	var names []string
+	for _, cmd := range commandTable() {
+		if sub, ok := strings.CutPrefix(cmd.name, group+":"); ok {
+			names = append(names, sub)
+		}
+	}
+	return names
+}
+
+// resolveCommand returns the command name at the start of args and the arguments following it,
+// joining a command group and its subcommand written as separate words
+func resolveCommand(args []string) (string, []string) {
+	if len(args) > 1 && slices.Contains(commandGroups, args[0]) && !strings.HasPrefix(args[1], "-") {
+		return args[0] + ":" + args[1], args[2:]
+	}
+	return args[0], args[1:]
+}
+
+// findCommand returns the subcommand with the given name
+func findCommand(name string) (cliCommand, bool) {
+	for _, cmd := range commandTable() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	custom, _ := customCommands()
+	for _, cmd := range custom {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return cliCommand{}, false
+}
+
+// allCommands returns the built-in commands followed by the custom generators of the config
+func allCommands() []cliCommand {
+	custom, _ := customCommands()
+	return append(commandTable(), custom...)
+}
+
+// customCommands returns a make:<name> command for each generator declared in the config
+func customCommands() ([]cliCommand, error) {
+	// The commands are listed before running one, so a missing config file is not worth a log line
+	out := log.Writer()
+	log.SetOutput(io.Discard)
+	generators, err := commands.CustomGenerators()
+	log.SetOutput(out)
+	if err != nil {
+		return nil, err
+	}
+
+	builtin := map[string]bool{}
+	for _, cmd := range commandTable() {
+		builtin[cmd.name] = true
+	}
+
+	var cmds []cliCommand
+	for _, generator := range generators {
+		name := "make:" + generator.Name
+		if builtin[name] {
+			return nil, fmt.Errorf("generators.%s conflicts with the built-in %s command", generator.Name, name)
+		}
+		summary := generator.Description
+		if summary == "" {
+			summary = fmt.Sprintf("Create %s artifacts from custom templates", generator.Name)
+		}
+		cmds = append(cmds, cliCommand{name, "<Name>... [flags]", summary, handleMakeCustom(generator.Name), completeNone, true})
+	}
+	return cmds, nil
+}
+
+// run dispatches the arguments to a subcommand, or starts interactive mode without one
+func run(args []string) error {
+	global := flag.NewFlagSet("flart", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	showVersion := global.Bool("version", false, "Show version information")
+	global.BoolVar(showVersion, "v", false, "Show version information")
+	showHelp := global.Bool("help", false, "Show help")
+	global.BoolVar(showHelp, "h", false, "Show help")
+	defineProjectDirFlag(global)
+	definePromptFlags(global)
+
+	if err := global.Parse(args); err != nil {
+		return usageErrorf("", "%v", err)
+	}
+	if *showVersion {
+		printVersion()
+		return nil
+	}
+	if *showHelp {
+		printHelp()
+		return nil
+	}
+
+	args = global.Args()
+	if len(args) == 0 {
+		return handleInteractive()
+	}
+
+	name := args[0]
+	switch name {
+	case "help":
+		if len(args) > 1 {
+			name, _ := resolveCommand(args[1:])
+			cmd, ok := findCommand(name)
+			if !ok {
+				return unknownCommand(name)
+			}
+			fs := newCommandFlagSet(cmd)
+			cmd.setup(fs)
+			printCommandUsage(cmd, fs)
+			return nil
+		}
+		printHelp()
+		return nil
+	case "version":
+		printVersion()
+		return nil
+	case cmdComplete:
+		for _, candidate := range completeArgs(args[1:]) {
+			fmt.Println(candidate)
+		}
+		return nil
+	}
+
+	name, rest := resolveCommand(args)
+	cmd, ok := findCommand(name)
+	if !ok {
+		return unknownCommand(name)
+	}
+	fs := newCommandFlagSet(cmd)
+	err := cmd.setup(fs)(rest)
+
+	// The plan is only worth reading when the whole command could be planned
+	if utils.DryRun() {
+		if err != nil {
+			utils.EndDryRun(io.Discard)
+		} else {
+			utils.EndDryRun(os.Stdout)
+		}
+	}
+	return err
+}
+
+// newCommandFlagSet creates the flag set of a subcommand with the shared flags.
+// Errors are reported by execute, and the usage is printed by parseFlags on --help.
+func newCommandFlagSet(cmd cliCommand) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	defineProjectDirFlag(fs)
+	if cmd.generates {
+		definePromptFlags(fs)
+		fs.BoolFunc("dry-run", "Print the planned changes as unified diffs and the commands to run, without touching any file", func(raw string) error {
+			enabled, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for --dry-run", raw)
+			}
+			if enabled && !utils.DryRun() {
+				utils.BeginDryRun()
+			}
+			return nil
+		})
+	}
+	return fs
+}
+
+// defineProjectDirFlag adds --project-dir, overriding projectDir from the config
+func defineProjectDirFlag(fs *flag.FlagSet) {
+	fs.Func("project-dir", "Flutter project `directory`, overrides projectDir", func(value string) error {
+		config.Override(config.Overrides{ProjectDir: &value})
+		return nil
+	})
+}
+
+// definePromptFlags adds the flags that answer prompts ahead of time, for CI and scripts
+func definePromptFlags(fs *flag.FlagSet) {
+	setPolicy := func(name string, policy commands.OverwritePolicy) error {
+		if current := commands.CurrentOverwritePolicy(); current != commands.OverwriteAsk && current != policy {
+			return fmt.Errorf("--%s conflicts with an earlier overwrite flag", name)
+		}
+		commands.SetOverwritePolicy(policy)
+		return nil
+	}
+	policyFlag := func(name string, policy commands.OverwritePolicy) func(string) error {
+		return func(raw string) error {
+			enabled, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for --%s", raw, name)
+			}
+			if !enabled {
+				return nil
+			}
+			return setPolicy(name, policy)
+		}
+	}
+
+	fs.BoolFunc("yes", "Answer yes to every prompt, overwriting existing files", policyFlag("yes", commands.OverwriteAlways))
+	fs.BoolFunc("force", "Overwrite existing files without asking", policyFlag("force", commands.OverwriteAlways))
+	fs.BoolFunc("no-overwrite", "Keep existing files, skipping the artifacts that would replace them", policyFlag("no-overwrite", commands.OverwriteNever))
+	fs.Func("on-conflict", "What to do with existing files: ask, overwrite, skip, new or merge", func(value string) error {
+		policy, err := commands.ParseOverwritePolicy(value)
+		if err != nil {
+			return err
+		}
+		return setPolicy("on-conflict", policy)
+	})
+	fs.BoolFunc("non-interactive", "Never prompt, failing when an answer is needed, which is the default without a terminal", func(raw string) error {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for --non-interactive", raw)
+		}
+		commands.SetNonInteractive(enabled)
+		return nil
+	})
+}
+
+// defineOverrideFlag adds a boolean flag that overrides a config value when given
+func defineOverrideFlag(fs *flag.FlagSet, name, usage string, set func(value *bool) config.Overrides) {
+	fs.BoolFunc(name, usage, func(raw string) error {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for --%s", raw, name)
+		}
+		config.Override(set(&value))
+		return nil
+	})
+}
+
+// parseFlags parses the flags of a subcommand, returning positional arguments found anywhere
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	rest := args
+	for len(rest) > 0 {
+		for len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+			positional = append(positional, rest[0])
+			rest = rest[1:]
+		}
+		if len(rest) == 0 {
+			break
+		}
+		if err := fs.Parse(rest); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				if cmd, ok := findCommand(fs.Name()); ok {
+					printCommandUsage(cmd, fs)
+				}
+				return nil, err
+			}
+			return nil, usageErrorf(fs.Name(), "%v", err)
+		}
+
+		// Everything after a -- terminator is positional
+		consumed := len(rest) - fs.NArg()
+		if consumed > 0 && rest[consumed-1] == "--" {
+			positional = append(positional, fs.Args()...)
+			break
+		}
+		rest = fs.Args()
+	}
+
+	return positional, nil
+}
+
+// parseArgs parses flags and checks that the number of positional arguments is within bounds
+func parseArgs(fs *flag.FlagSet, args []string, min, max int) ([]string, error) {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) < min {
+		return nil, usageErrorf(fs.Name(), "%s: missing arguments", fs.Name())
+	}
+	if max >= 0 && len(positional) > max {
+		return nil, usageErrorf(fs.Name(), "%s: unexpected arguments: %s", fs.Name(), strings.Join(positional[max:], " "))
+	}
+	return positional, nil
+}
+
+// unknownCommand builds a usage error with "did you mean" suggestions
+func unknownCommand(name string) error {
+	if slices.Contains(commandGroups, name) {
+		return usageErrorf("", "%s expects a subcommand, one of %s", name, strings.Join(groupSubcommands(name), ", "))
+	}
+	msg := fmt.Sprintf("unknown command %q", name)
+	if suggestions := suggestCommands(name); len(suggestions) > 0 {
+		msg += "\n\nDid you mean this?\n\t" + strings.Join(suggestions, "\n\t")
+	}
+	if _, err := customCommands(); err != nil && strings.HasPrefix(name, "make:") {
+		msg += fmt.Sprintf("\n\nCustom generators are unavailable: %v", err)
+	}
+	return usageErrorf("", "%s", msg)
+}
+
+// suggestCommands returns command names close to name, closest first
+func suggestCommands(name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	var candidates []candidate
+	for _, cmd := range allCommands() {
+		distance := levenshtein(name, cmd.name)
+		_, suffix, _ := strings.Cut(cmd.name, ":")
+		switch {
+		case distance <= 2 || distance <= len(cmd.name)/3:
+		case strings.HasPrefix(cmd.name, name) || name == suffix:
+			distance = len(cmd.name)
+		default:
+			continue
+		}
+		candidates = append(candidates, candidate{cmd.name, distance})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+	var names []string
+	for i, c := range candidates {
+		if i == 3 {
+			break
+		}
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// printHelp prints the generated top level usage
+func printHelp() {
+	fmt.Println("Flart generates Flutter models, screens and more following clean architecture.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  flart                          Start interactive mode")
+	fmt.Println("  flart <command> [arguments]    Run a command")
+	fmt.Println()
+	fmt.Println("Commands:")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	for _, cmd := range allCommands() {
+		fmt.Fprintf(w, "  %s\t%s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "  %s\t%s\n", "help [command]", "Show help for flart or a command")
+	fmt.Fprintf(w, "  %s\t%s\n", "version", "Show version information")
+	w.Flush()
+
+	fmt.Println()
+	fmt.Println("Global flags:")
+	fmt.Println("  -h, --help             Show help")
+	fmt.Println("  -v, --version          Show version information")
+	fmt.Println("  --project-dir <dir>    Flutter project directory, overrides projectDir")
+	fmt.Println("  --yes, --force         Overwrite existing files without asking")
+	fmt.Println("  --no-overwrite         Keep existing files, skipping the artifacts that would replace them")
+	fmt.Println("  --on-conflict <policy> ask, overwrite, skip, new (write <file>.new) or merge (three-way)")
+	fmt.Println("  --non-interactive      Never prompt, the default when stdin is not a terminal")
+	fmt.Println()
+	fmt.Printf("Commands of the %s group can also be written as two words, e.g. 'flart pack install'.\n", strings.Join(commandGroups, ", "))
+	fmt.Println("Run 'flart <command> --help' for the flags of a command.")
+	fmt.Printf("Exit codes: %d on success, %d when a command fails, %d on invalid usage.\n", exitOK, exitFailure, exitUsage)
+}
+
+// printCommandUsage prints the generated usage of a subcommand
+func printCommandUsage(cmd cliCommand, fs *flag.FlagSet) {
+	fmt.Printf("Usage: flart %s %s\n\n%s\n\nFlags:\n", cmd.name, cmd.args, cmd.summary)
+
+	fs.SetOutput(os.Stdout)
+	fs.PrintDefaults()
+	fs.SetOutput(io.Discard)
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"flag"
+	"flart/internal/commands"
+	"flart/internal/config"
+	"flart/internal/utils"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+// completion is the kind of value suggested for an argument
+type completion int
+
+const (
+	completeNone completion = iota
+	completeFiles
+	completeDirs
+	completeModels
+	completeScreens
+	completeRepositories
+	completeTypes
+	completeFields
+	completeShells
+	completeConflictPolicies
+)
+
+// Directives printed instead of candidates when the shell should complete paths itself
+const (
+	directiveFiles = ":file"
+	directiveDirs  = ":dir"
+)
+
+// flagCompletions maps flag names to the values they accept, whatever the command
+var flagCompletions = map[string]completion{
+	"project-dir": completeDirs,
+	"list":        completeModels,
+	"tabs":        completeScreens,
+	"repo":        completeRepositories,
+	"returns":     completeTypes,
+	"fields":      completeFields,
+	"params":      completeFields,
+	"form":        completeFields,
+	"on-conflict": completeConflictPolicies,
+}
+
+// listFlags hold comma separated values, each of which is completed
+var listFlags = map[string]bool{"tabs": true}
+
+var shells = []string{"bash", "zsh", "fish"}
+
+func handleCompletion(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		positional, err := parseArgs(fs, args, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		var script string
+		switch positional[0] {
+		case "bash":
+			script = bashCompletion
+		case "zsh":
+			script = zshCompletion
+		case "fish":
+			script = fishCompletion
+		default:
+			return usageErrorf(cmdCompletion, "unsupported shell %q, expected one of %s", positional[0], strings.Join(shells, ", "))
+		}
+
+		_, err = io.WriteString(os.Stdout, script)
+		return err
+	}
+}
+
+// completeArgs returns the candidates for the last argument, given the arguments before it
+func completeArgs(args []string) []string {
+	// Loading the config logs when no config file exists, which would corrupt the prompt
+	log.SetOutput(io.Discard)
+
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	current := args[len(args)-1]
+	previous := args[:len(args)-1]
+
+	// Artifacts are looked up in the project given on the command line, if any
+	for i, arg := range previous {
+		if isFlag(arg, "project-dir") && i+1 < len(previous) {
+			config.Override(config.Overrides{ProjectDir: &previous[i+1]})
+		}
+	}
+
+	// Skip the global flags to find the command
+	i := 0
+	for i < len(previous) && strings.HasPrefix(previous[i], "-") {
+		if isFlag(previous[i], "project-dir") {
+			i++
+		}
+		i++
+	}
+
+	if i >= len(previous) {
+		if len(previous) > 0 && isFlag(previous[len(previous)-1], "project-dir") {
+			return []string{directiveDirs}
+		}
+		if strings.HasPrefix(current, "-") {
+			return filterPrefix([]string{"--help", "--version", "--project-dir"}, current)
+		}
+		return filterPrefix(commandNames(), current)
+	}
+
+	name, rest := previous[i], previous[i+1:]
+	if slices.Contains(commandGroups, name) {
+		if len(rest) == 0 {
+			return filterPrefix(groupSubcommands(name), current)
+		}
+		name, rest = resolveCommand(previous[i:])
+	}
+	switch name {
+	case "help":
+		if len(rest) == 0 {
+			return filterPrefix(commandNames(), current)
+		}
+		return nil
+	case cmdComplete, "version":
+		return nil
+	}
+
+	cmd, ok := findCommand(name)
+	if !ok {
+		return nil
+	}
+	fs := newCommandFlagSet(cmd)
+	cmd.setup(fs)
+
+	// Value of a flag given as a separate argument
+	if len(rest) > 0 {
+		if last := rest[len(rest)-1]; strings.HasPrefix(last, "-") {
+			if f := fs.Lookup(strings.TrimLeft(last, "-")); f != nil && !isBoolFlag(f) {
+				return completeFlagValue(f.Name, current)
+			}
+		}
+	}
+
+	if strings.HasPrefix(current, "-") {
+		// Value of a flag given as --flag=value
+		if flagName, value, ok := strings.Cut(strings.TrimLeft(current, "-"), "="); ok {
+			prefix := current[:len(current)-len(value)]
+			var candidates []string
+			for _, candidate := range completeFlagValue(flagName, value) {
+				if candidate != directiveFiles && candidate != directiveDirs {
+					candidates = append(candidates, prefix+candidate)
+				}
+			}
+			return candidates
+		}
+
+		var flags []string
+		fs.VisitAll(func(f *flag.Flag) {
+			flags = append(flags, "--"+f.Name)
+		})
+		return filterPrefix(append(flags, "--help"), current)
+	}
+
+	return completeValue(cmd.complete, current, false)
+}
+
+// isFlag reports whether arg is the named flag with one or two dashes
+func isFlag(arg, name string) bool {
+	return arg == "-"+name || arg == "--"+name
+}
+
+// isBoolFlag reports whether a flag takes no value
+func isBoolFlag(f *flag.Flag) bool {
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
+
+// completeFlagValue returns the candidates for the value of a flag
+func completeFlagValue(name, current string) []string {
+	return completeValue(flagCompletions[name], current, listFlags[name])
+}
+
+// completeValue returns the candidates of a kind that start with current.
+// For lists, only the part after the last comma is completed.
+func completeValue(kind completion, current string, list bool) []string {
+	switch kind {
+	case completeFiles:
+		return []string{directiveFiles}
+	case completeDirs:
+		return []string{directiveDirs}
+	case completeShells:
+		return filterPrefix(shells, current)
+	case completeConflictPolicies:
+		var policies []string
+		for _, policy := range commands.OverwritePolicies {
+			policies = append(policies, string(policy))
+		}
+		return filterPrefix(policies, current)
+	case completeNone:
+		return nil
+	}
+
+	projectDir, ok := completionProjectDir()
+	if !ok {
+		return nil
+	}
+
+	var prefix string
+	if list || kind == completeFields {
+		if i := strings.LastIndex(current, ","); i >= 0 {
+			prefix, current = current[:i+1], current[i+1:]
+		}
+	}
+
+	var values []string
+	var err error
+	switch kind {
+	case completeModels:
+		values, err = utils.ProjectModels(projectDir)
+	case completeScreens:
+		values, err = utils.ProjectScreens(projectDir)
+	case completeRepositories:
+		values, err = utils.ProjectRepositories(projectDir)
+	case completeTypes:
+		values, err = utils.ProjectFieldTypes(projectDir)
+	case completeFields:
+		// Only the type of a name:Type spec is completed
+		name, _, ok := strings.Cut(current, ":")
+		if !ok {
+			return nil
+		}
+		prefix += name + ":"
+		current = current[len(name)+1:]
+		values, err = utils.ProjectFieldTypes(projectDir)
+	}
+	if err != nil {
+		return nil
+	}
+
+	candidates := filterPrefix(values, current)
+	for i, candidate := range candidates {
+		candidates[i] = prefix + candidate
+	}
+	return candidates
+}
+
+// completionProjectDir returns the project directory from the config and --project-dir
+func completionProjectDir() (string, bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", false
+	}
+	return *cfg.ProjectDir, true
+}
+
+// commandNames returns the names completed in place of a command
+func commandNames() []string {
+	names := append([]string{"help", "version"}, commandGroups...)
+	for _, cmd := range allCommands() {
+		names = append(names, cmd.name)
+	}
+	return names
+}
+
+// filterPrefix returns the values starting with prefix
+func filterPrefix(values []string, prefix string) []string {
+	var matches []string
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			matches = append(matches, value)
+		}
+	}
+	return matches
+}
+
+// The completion scripts ask flart __complete for candidates, so artifact names follow the project.
+// A directive in place of candidates makes the shell complete paths instead.
+
+const bashCompletion = `# bash completion for flart
+# Load with: source <(flart completion bash)
+
+_flart() {
+    local line=${COMP_LINE:0:COMP_POINT}
+    local -a words
+    read -ra words <<< "$line"
+    if [[ $line == *[[:space:]] ]]; then
+        words+=("")
+    fi
+    local cur=${words[${#words[@]}-1]}
+
+    local out
+    out=$(flart __complete "${words[@]:1}" 2>/dev/null)
+    case $out in
+    :file)
+        COMPREPLY=($(compgen -f -- "$cur"))
+        return
+        ;;
+    :dir)
+        COMPREPLY=($(compgen -d -- "$cur"))
+        return
+        ;;
+    esac
+
+    local IFS=$'\n'
+    COMPREPLY=($(compgen -W "$out" -- "$cur"))
+
+    # Bash breaks words on colons, so only the part after the last colon is replaced
+    if [[ $cur == *:* && $COMP_WORDBREAKS == *:* ]]; then
+        local colon_prefix=${cur%"${cur##*:}"}
+        local i
+        for i in "${!COMPREPLY[@]}"; do
+            COMPREPLY[$i]=${COMPREPLY[$i]#"$colon_prefix"}
+        done
+    fi
+}
+
+complete -F _flart flart
+`
+
+const zshCompletion = `#compdef flart
+# Load with: source <(flart completion zsh)
+
+_flart() {
+    local -a candidates
+    candidates=("${(@f)$(flart __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+
+    case ${candidates[1]} in
+    :file)
+        _files
+        ;;
+    :dir)
+        _files -/
+        ;;
+    *)
+        compadd -Q -- ${candidates:#}
+        ;;
+    esac
+}
+
+if [[ $funcstack[1] == _flart ]]; then
+    _flart "$@"
+else
+    compdef _flart flart
+fi
+`
+
+const fishCompletion = `# fish completion for flart
+# Load with: flart completion fish | source
+
+function __flart_complete
+    set -l args (commandline -opc)[2..-1] (commandline -ct)
+    set -l candidates (flart __complete $args 2>/dev/null)
+    switch "$candidates[1]"
+        case :file
+            __fish_complete_path (commandline -ct)
+        case :dir
+            __fish_complete_directories (commandline -ct)
+        case '*'
+            printf '%s\n' $candidates
+    end
+end
+
+complete -c flart -f -a '(__flart_complete)'
+`
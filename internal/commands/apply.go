@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"flart/internal/utils"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fieldList accepts field specs either as a YAML list or as one comma separated string
+type fieldList []string
+
+func (l *fieldList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = fieldList{node.Value}
+		return nil
+	}
+
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// Manifest describes a batch of artifacts generated by flart apply
+type Manifest struct {
+	Enums []struct {
+		Name   string   `yaml:"name"`
+		Values []string `yaml:"values"`
+	} `yaml:"enums"`
+	Models []struct {
+		Name   string    `yaml:"name"`
+		Fields fieldList `yaml:"fields"`
+	} `yaml:"models"`
+	Repositories []struct {
+		Name  string `yaml:"name"`
+		Model string `yaml:"model"`
+	} `yaml:"repositories"`
+	Screens []struct {
+		Name    string    `yaml:"name"`
+		Form    fieldList `yaml:"form"`
+		List    string    `yaml:"list"`
+		Tabs    []string  `yaml:"tabs"`
+		TopTabs bool      `yaml:"topTabs"`
+	} `yaml:"screens"`
+}
+
+// Artifact kinds in the order they are generated when nothing else decides
+const (
+	kindEnum       = "enum"
+	kindModel      = "model"
+	kindRepository = "repository"
+	kindScreen     = "screen"
+)
+
+// artifact is a single manifest entry with the artifacts it depends on
+type artifact struct {
+	kind     string
+	name     string
+	deps     []string
+	generate func() error
+}
+
+// artifactKey identifies an artifact by kind and PascalCase name
+func artifactKey(kind, name string) string {
+	return kind + ":" + utils.ToPascalCase(name)
+}
+
+// LoadManifest reads and parses a manifest file
+func LoadManifest(file string) (*Manifest, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", file, err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", file, err)
+	}
+	return &manifest, nil
+}
+
+// Apply generates every artifact in the manifest, running dependency, format and build steps once
+func Apply(manifestFile string) error {
+	manifest, err := LoadManifest(manifestFile)
+	if err != nil {
+		return err
+	}
+
+	artifacts, err := manifestArtifacts(manifest)
+	if err != nil {
+		return err
+	}
+
+	ordered, err := orderArtifacts(artifacts)
+	if err != nil {
+		return err
+	}
+	if len(ordered) == 0 {
+		return fmt.Errorf("manifest %s does not declare any artifacts", manifestFile)
+	}
+
+	return Transaction(func() error {
+		for _, a := range ordered {
+			if err := a.generate(); err != nil {
+				return fmt.Errorf("failed to create %s %s: %w", a.kind, a.name, err)
+			}
+			if !utils.DryRun() {
+				fmt.Printf("Created %s %s\n", a.kind, a.name)
+			}
+		}
+		return nil
+	})
+}
+
+// manifestArtifacts converts manifest entries into artifacts, validating names and options
+func manifestArtifacts(manifest *Manifest) ([]*artifact, error) {
+	var artifacts []*artifact
+	declared := map[string]bool{}
+
+	add := func(a *artifact) error {
+		if strings.TrimSpace(a.name) == "" {
+			return fmt.Errorf("every %s needs a name", a.kind)
+		}
+		key := artifactKey(a.kind, a.name)
+		if declared[key] {
+			return fmt.Errorf("%s %s is declared more than once", a.kind, a.name)
+		}
+		declared[key] = true
+		artifacts = append(artifacts, a)
+		return nil
+	}
+
+	for _, e := range manifest.Enums {
+		name, values := e.Name, e.Values
+		err := add(&artifact{kind: kindEnum, name: name, generate: func() error {
+			return CreateEnum(name, values)
+		}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, m := range manifest.Models {
+		name := m.Name
+		fields, err := utils.ParseFields(strings.Join(m.Fields, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid fields for model %s: %w", name, err)
+		}
+
+		var deps []string
+		for _, f := range fields {
+			for _, typeName := range typeNamePattern.FindAllString(f.Type, -1) {
+				deps = append(deps, artifactKey(kindEnum, typeName), artifactKey(kindModel, typeName))
+			}
+		}
+
+		err = add(&artifact{kind: kindModel, name: name, deps: deps, generate: func() error {
+			return CreateModel(name, ModelOptions{Fields: fields})
+		}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, r := range manifest.Repositories {
+		name, model := r.Name, r.Model
+		var deps []string
+		if model != "" {
+			deps = append(deps, artifactKey(kindModel, model))
+		}
+		err := add(&artifact{kind: kindRepository, name: name, deps: deps, generate: func() error {
+			return CreateRepository(name, model)
+		}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range manifest.Screens {
+		name := s.Name
+		form, err := utils.ParseFields(strings.Join(s.Form, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid form fields for screen %s: %w", name, err)
+		}
+		opts := ScreenOptions{Form: form, List: s.List, Tabs: s.Tabs, TopTabs: s.TopTabs}
+
+		var deps []string
+		if s.List != "" {
+			deps = append(deps, artifactKey(kindModel, s.List))
+		}
+		for _, tab := range s.Tabs {
+			deps = append(deps, artifactKey(kindScreen, tab))
+		}
+
+		err = add(&artifact{kind: kindScreen, name: name, deps: deps, generate: func() error {
+			return CreateScreen(name, opts)
+		}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Only keep dependencies on artifacts declared in the manifest, the rest must exist on disk
+	for _, a := range artifacts {
+		var deps []string
+		for _, dep := range a.deps {
+			if declared[dep] && dep != artifactKey(a.kind, a.name) {
+				deps = append(deps, dep)
+			}
+		}
+		a.deps = deps
+	}
+
+	return artifacts, nil
+}
+
+// orderArtifacts sorts artifacts so that each comes after its dependencies, keeping manifest order otherwise
+func orderArtifacts(artifacts []*artifact) ([]*artifact, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	byKey := map[string]*artifact{}
+	for _, a := range artifacts {
+		byKey[artifactKey(a.kind, a.name)] = a
+	}
+
+	state := map[string]int{}
+	var ordered []*artifact
+	var visit func(a *artifact, path []string) error
+	visit = func(a *artifact, path []string) error {
+		key := artifactKey(a.kind, a.name)
+		switch state[key] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular dependency: %s", strings.Join(append(path, key), " -> "))
+		}
+
+		state[key] = visiting
+		for _, dep := range a.deps {
+			if err := visit(byKey[dep], append(path, key)); err != nil {
+				return err
+			}
+		}
+		state[key] = done
+		ordered = append(ordered, a)
+		return nil
+	}
+
+	for _, a := range artifacts {
+		if err := visit(a, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// assetsFile is where the typed asset constants are written, relative to the project
+const assetsFile = "lib/gen/assets.dart"
+
+// assetsPollInterval is how often watch mode checks for asset changes
+const assetsPollInterval = time.Second
+
+// assetsQuietPeriod is how long assets must stay unchanged before watch mode regenerates, so that
+// a burst of changes, such as copying a folder of images, is regenerated and journaled once
+const assetsQuietPeriod = 2 * time.Second
+
+// GenerateAssets writes typed asset constants, optionally regenerating them whenever assets change
+func GenerateAssets(watch bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	projectDir := *cfg.ProjectDir
+
+	if err := Transaction(func() error { return writeAssets(projectDir) }); err != nil {
+		return err
+	}
+	if !watch {
+		return nil
+	}
+
+	fmt.Println("Watching assets for changes, press Ctrl+C to stop")
+	watcher, err := newAssetsWatcher(projectDir, assetsPollInterval, assetsQuietPeriod)
+	if err != nil {
+		return err
+	}
+	for {
+		if err := watcher.wait(); err != nil {
+			return err
+		}
+
+		// Keep watching when the pubspec is temporarily invalid
+		if err := Transaction(func() error { return writeAssets(projectDir) }); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	}
+}
+
+// assetsWatcher detects bursts of changes to the pubspec and the declared assets
+type assetsWatcher struct {
+	projectDir string
+	poll       time.Duration
+	quiet      time.Duration
+	// last is the snapshot the constants were last generated from
+	last string
+}
+
+// newAssetsWatcher starts watching the assets as they are now
+func newAssetsWatcher(projectDir string, poll, quiet time.Duration) (*assetsWatcher, error) {
+	last, err := assetsSnapshot(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	return &assetsWatcher{projectDir: projectDir, poll: poll, quiet: quiet, last: last}, nil
+}
+
+// wait returns once the assets changed and then stayed unchanged for the quiet period
+func (w *assetsWatcher) wait() error {
+	for {
+		time.Sleep(w.poll)
+		current, err := assetsSnapshot(w.projectDir)
+		if err != nil {
+			return err
+		}
+		if current == w.last {
+			continue
+		}
+
+		// Let the burst settle
+		for {
+			time.Sleep(w.quiet)
+			next, err := assetsSnapshot(w.projectDir)
+			if err != nil {
+				return err
+			}
+			if next == current {
+				break
+			}
+			current = next
+		}
+		w.last = current
+		return nil
+	}
+}
+
+// writeAssets scans the declared assets and writes the constants file
+func writeAssets(projectDir string) error {
+	declared, err := utils.GetPubspecAssets(projectDir)
+	if err != nil {
+		return err
+	}
+
+	assets, err := utils.ScanAssets(projectDir, declared)
+	if err != nil {
+		return err
+	}
+
+	undeclared, err := utils.UndeclaredAssets(projectDir, declared)
+	if err != nil {
+		return err
+	}
+	for _, file := range undeclared {
+		fmt.Printf("Warning: %s is not declared in pubspec.yaml\n", file)
+	}
+
+	outputFile := filepath.Join(projectDir, assetsFile)
+	if err := utils.MkdirAll(filepath.Dir(outputFile)); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(outputFile), err)
+	}
+	content, err := templates.GenerateAssets(assets, declared.Fonts)
+	if err != nil {
+		return err
+	}
+	if err := writeAndFormatFile(outputFile, content, projectDir); err != nil {
+		return err
+	}
+
+	if !utils.DryRun() {
+		fmt.Printf("Generated %d asset constant(s) in %s\n", len(assets), assetsFile)
+	}
+	return nil
+}
+
+// assetsSnapshot fingerprints pubspec.yaml and the asset roots to detect changes
+func assetsSnapshot(projectDir string) (string, error) {
+	var b strings.Builder
+
+	pubspec := filepath.Join(projectDir, "pubspec.yaml")
+	info, err := os.Stat(pubspec)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pubspec.yaml: %w", err)
+	}
+	fmt.Fprintf(&b, "%s:%d:%d\n", pubspec, info.Size(), info.ModTime().UnixNano())
+
+	declared, err := utils.GetPubspecAssets(projectDir)
+	if err != nil {
+		// The pubspec change itself is enough to trigger a regeneration
+		return b.String(), nil
+	}
+
+	for _, root := range utils.AssetRoots(declared) {
+		rootDir := filepath.Join(projectDir, root)
+		if _, err := os.Stat(rootDir); err != nil {
+			continue
+		}
+		err := filepath.WalkDir(rootDir, func(file string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&b, "%s:%d:%d\n", file, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssetsWatchRegeneratesOncePerBurst(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "pubspec.yaml",
+		"name: app\n\ndependencies:\n  flutter:\n    sdk: flutter\n\nflutter:\n  assets:\n    - assets/images/\n")
+	writeProjectFile(t, projectDir, "assets/images/logo.png", "logo")
+
+	watcher, err := newAssetsWatcher(projectDir, 10*time.Millisecond, 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Copy images one after the other, faster than the quiet period
+	copied := make(chan struct{})
+	go func() {
+		defer close(copied)
+		for i := 1; i <= 3; i++ {
+			time.Sleep(30 * time.Millisecond)
+			file := filepath.Join(projectDir, "assets", "images", fmt.Sprintf("photo%d.png", i))
+			if err := os.WriteFile(file, []byte("photo"), 0644); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+
+	if err := watcher.wait(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-copied:
+	default:
+		t.Fatal("the watcher regenerated before the burst of changes ended")
+	}
+	if err := Transaction(func() error { return writeAssets(projectDir) }); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	content := readProjectFile(t, projectDir, "lib/gen/assets.dart")
+	for i := 1; i <= 3; i++ {
+		if want := fmt.Sprintf("photo%d.png", i); !strings.Contains(content, want) {
+			t.Errorf("constants do not contain %s:\n%s", want, content)
+		}
+	}
+	entries, err := loadHistory(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("the burst wrote %d history entries, want 1", len(entries))
+	}
+}
@@ -0,0 +1,252 @@
+package commands
+
+import (
+	"errors"
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"maps"
+)
+
+// Statuses reported for each artifact of a batch
+const (
+	StatusCreated = "created"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+	// StatusRolledBack is reported for created artifacts when a later step of the batch failed
+	StatusRolledBack = "rolled back"
+)
+
+// BatchResult is the outcome of creating one artifact in a batch
+type BatchResult struct {
+	Name   string
+	Status string
+	Err    error
+}
+
+// generationBatch defers the slow per-artifact steps so that they run once for many artifacts
+type generationBatch struct {
+	cfg         *config.Config
+	formatFiles []string
+	buildRunner bool
+	genL10n     bool
+	// bases holds the generated files whose content is recorded for later merges
+	bases map[string]*string
+}
+
+// batch is set while several artifacts are generated in one run
+var batch *generationBatch
+
+// addFormatFile queues a file for the batched dart format run
+func (b *generationBatch) addFormatFile(filePath string) {
+	for _, existing := range b.formatFiles {
+		if existing == filePath {
+			return
+		}
+	}
+	b.formatFiles = append(b.formatFiles, filePath)
+}
+
+// batchSavepoint marks the staged writes and deferred steps of a batch so that a failed
+// artifact can be undone without affecting the others
+type batchSavepoint struct {
+	files       *utils.Savepoint
+	formatFiles int
+	buildRunner bool
+	genL10n     bool
+	bases       map[string]*string
+}
+
+// newBatchSavepoint returns the current state of the batch
+func newBatchSavepoint() *batchSavepoint {
+	s := &batchSavepoint{files: utils.NewSavepoint()}
+	if batch != nil {
+		s.formatFiles = len(batch.formatFiles)
+		s.buildRunner = batch.buildRunner
+		s.genL10n = batch.genL10n
+		s.bases = maps.Clone(batch.bases)
+	}
+	return s
+}
+
+// rollbackToBatchSavepoint drops the writes, dependencies and deferred steps added since the savepoint
+func rollbackToBatchSavepoint(s *batchSavepoint) {
+	utils.RollbackToSavepoint(s.files)
+	if batch == nil {
+		return
+	}
+	batch.formatFiles = batch.formatFiles[:s.formatFiles]
+	batch.buildRunner = s.buildRunner
+	batch.genL10n = s.genL10n
+	batch.bases = s.bases
+}
+
+// beginBatch starts sharing cfg and deferring dependencies, formatting, gen-l10n and build_runner
+func beginBatch(cfg *config.Config) {
+	batch = &generationBatch{cfg: cfg}
+	utils.BeginDependencyBatch()
+}
+
+// loadConfig returns the batch's config, loading it only outside of a batch
+func loadConfig() (*config.Config, error) {
+	if batch != nil && batch.cfg != nil {
+		return batch.cfg, nil
+	}
+	return config.Load()
+}
+
+// Transaction runs generate as one batch whose writes are staged and then applied together.
+// If generating, formatting, adding dependencies or build_runner fails, every created file
+// is removed and every modified file restored, and the rolled back files are reported.
+func Transaction(generate func() error) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := templates.Configure(*cfg.ProjectDir, cfg); err != nil {
+		return err
+	}
+	return transaction(cfg, generate)
+}
+
+// transaction runs generate as one batch with an already loaded config and templates
+func transaction(cfg *config.Config, generate func() error) error {
+	beginBatch(cfg)
+	started := utils.BeginTransaction()
+	err := generate()
+	if err == nil {
+		err = addPackDependencies(*cfg.ProjectDir)
+	}
+	if err != nil {
+		cancelBatch()
+		if started {
+			utils.EndTransaction()
+		}
+		return err
+	}
+
+	return commitBatch(*cfg.ProjectDir, started)
+}
+
+// commitBatch applies the staged writes and runs the deferred steps, rolling back on failure.
+// Without an own transaction, as in a dry run, only the deferred steps run.
+func commitBatch(projectDir string, transaction bool) error {
+	if !transaction {
+		return flushBatch(projectDir)
+	}
+
+	var bases map[string]*string
+	if batch != nil {
+		bases = batch.bases
+	}
+
+	err := utils.ApplyTransaction()
+	if err == nil {
+		err = flushBatch(projectDir)
+	} else {
+		cancelBatch()
+	}
+	if err == nil {
+		// The generation is already applied, so a journal failure only loses the undo
+		if err := recordHistory(projectDir, utils.EndTransaction()); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+		if err := recordBases(projectDir, bases); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+		return nil
+	}
+
+	rolledBack, rollbackErr := utils.RollbackTransaction()
+	if len(rolledBack) > 0 {
+		fmt.Printf("Rolled back %d file(s):\n", len(rolledBack))
+		for _, line := range rolledBack {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	if rollbackErr != nil {
+		return fmt.Errorf("%w, and %w", err, rollbackErr)
+	}
+	return err
+}
+
+// CreateBatch creates one artifact per name in a single transaction, sharing a single config load
+// and running dependency, format and build steps once at the end. A failed artifact leaves
+// nothing behind and does not stop the others.
+func CreateBatch(names []string, create func(name string) error) ([]BatchResult, error) {
+	var results []BatchResult
+	err := Transaction(func() error {
+		seen := map[string]bool{}
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			savepoint := newBatchSavepoint()
+			err := create(name)
+			if err != nil {
+				rollbackToBatchSavepoint(savepoint)
+			}
+
+			switch {
+			case err == nil:
+				results = append(results, BatchResult{Name: name, Status: StatusCreated})
+			case errors.Is(err, ErrCancelled), errors.Is(err, ErrKept):
+				results = append(results, BatchResult{Name: name, Status: StatusSkipped, Err: err})
+			default:
+				results = append(results, BatchResult{Name: name, Status: StatusFailed, Err: err})
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		for i := range results {
+			if results[i].Status == StatusCreated {
+				results[i].Status = StatusRolledBack
+			}
+		}
+	}
+	return results, err
+}
+
+// cancelBatch ends the batch without running the deferred steps
+func cancelBatch() {
+	batch = nil
+	utils.CancelDependencyBatch()
+}
+
+// flushBatch ends the batch and runs each deferred step once
+func flushBatch(projectDir string) error {
+	current := batch
+	batch = nil
+
+	if err := utils.FlushDependencies(projectDir); err != nil {
+		return err
+	}
+	if current == nil {
+		return nil
+	}
+
+	if len(current.formatFiles) > 0 {
+		if err := utils.RunQuietCommand(projectDir, "dart", append([]string{"format"}, current.formatFiles...)...); err != nil {
+			return fmt.Errorf("failed to format %d generated files: %w", len(current.formatFiles), err)
+		}
+	}
+
+	if current.genL10n {
+		if err := runGenL10n(projectDir); err != nil {
+			return err
+		}
+	}
+
+	if current.buildRunner {
+		if err := runBuildRunner(projectDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"errors"
+	"flart/internal/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generateArtifacts creates an artifact with each generator that updates a barrel file
+func generateArtifacts() error {
+	return Transaction(func() error {
+		if err := CreateModel("User", ModelOptions{}); err != nil {
+			return err
+		}
+		if err := CreateScreen("Home", ScreenOptions{}); err != nil {
+			return err
+		}
+		if err := CreateWidget("Avatar", WidgetOptions{}); err != nil {
+			return err
+		}
+		return CreateDataSource("User", DataSourceOptions{Remote: true, Local: true})
+	})
+}
+
+func TestGenerateIntoFreshProject(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	if err := generateArtifacts(); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	barrels := map[string]string{
+		"lib/models/models.dart":   "export 'user.dart';\n",
+		"lib/screens/screens.dart": "export 'home/home.dart';\n",
+		"lib/widgets/widgets.dart": "export 'avatar.dart';\n",
+	}
+	for barrel, want := range barrels {
+		content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(barrel)))
+		if err != nil {
+			t.Fatalf("barrel not written: %v", err)
+		}
+		if string(content) != want {
+			t.Errorf("%s = %q, want %q", barrel, content, want)
+		}
+	}
+}
+
+func TestDryRunIntoFreshProject(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	utils.BeginDryRun()
+	err := generateArtifacts()
+	var plan strings.Builder
+	utils.EndDryRun(&plan)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+
+	for _, barrel := range []string{"lib/models/models.dart", "lib/screens/screens.dart", "lib/widgets/widgets.dart", "lib/data/data.dart"} {
+		if !strings.Contains(plan.String(), "Would create "+filepath.FromSlash(barrel)) {
+			t.Errorf("plan does not create %s:\n%s", barrel, plan.String())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "lib", "models")); !os.IsNotExist(err) {
+		t.Errorf("dry run created lib/models")
+	}
+}
+
+func TestBatchRollsBackFailedArtifact(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", `{"models": {"useFreezed": true}}`)
+
+	log := recordCommands(t)
+
+	// The model queues its freezed dependencies, formatting and build_runner before failing
+	results, err := CreateBatch([]string{"Home", "Order"}, func(name string) error {
+		if name == "Home" {
+			return CreateScreen(name, ScreenOptions{})
+		}
+		if err := CreateModel(name, ModelOptions{}); err != nil {
+			return err
+		}
+		return errors.New("generation failed")
+	})
+	if err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Status != StatusCreated || results[1].Status != StatusFailed {
+		t.Fatalf("results = %+v, want Home created and Order failed", results)
+	}
+
+	if !projectFileExists(projectDir, "lib/screens/home/home.dart") {
+		t.Errorf("the created screen was not written")
+	}
+	for _, file := range []string{"lib/models/order.dart", ".dart_tool/flart/base/lib/models/order.dart"} {
+		if projectFileExists(projectDir, file) {
+			t.Errorf("%s of the failed model was written", file)
+		}
+	}
+
+	commands, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, unwanted := range []string{"order.dart", "freezed", "build_runner"} {
+		if strings.Contains(string(commands), unwanted) {
+			t.Errorf("commands of the failed model ran, found %q in:\n%s", unwanted, commands)
+		}
+	}
+	if !strings.Contains(string(commands), "home/home.dart") {
+		t.Errorf("the created screen was not formatted:\n%s", commands)
+	}
+}
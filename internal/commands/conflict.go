@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"flart/internal/utils"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// baseDir holds the content of each file as last generated, relative to the project.
+// It is the base of three-way merges when the file is generated again.
+var baseDir = filepath.Join(".dart_tool", "flart", "base")
+
+// rememberBase records that a file was generated in the current batch. A nil content is read
+// back once the batch is applied, after formatting.
+func rememberBase(filePath string, content *string) {
+	if batch == nil {
+		return
+	}
+	if batch.bases == nil {
+		batch.bases = map[string]*string{}
+	}
+	batch.bases[filePath] = content
+}
+
+// basePath returns where the base of a project file is kept
+func basePath(projectDir, filePath string) (string, bool) {
+	rel, err := filepath.Rel(projectDir, filePath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return filepath.Join(projectDir, baseDir, rel), true
+}
+
+// recordBases saves the generated content of the files of an applied batch
+func recordBases(projectDir string, bases map[string]*string) error {
+	for filePath, content := range bases {
+		path, ok := basePath(projectDir, filePath)
+		if !ok {
+			continue
+		}
+
+		data := []byte{}
+		if content != nil {
+			data = []byte(*content)
+		} else {
+			var err error
+			if data, err = os.ReadFile(filePath); err != nil {
+				return fmt.Errorf("failed to read %s: %w", filePath, err)
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to record generated content of %s: %w", filePath, err)
+		}
+	}
+	return nil
+}
+
+// writeNewFile writes the formatted generated content next to an existing file as <file>.new
+func writeNewFile(filePath, content, projectDir string) error {
+	formatted, err := utils.FormatDart(projectDir, content)
+	if err != nil {
+		return err
+	}
+
+	newFile := filePath + ".new"
+	if err := utils.WriteFile(newFile, []byte(formatted)); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", newFile, err)
+	}
+	if !utils.DryRun() {
+		fmt.Printf("Kept %s, wrote the generated version to %s\n", filePath, newFile)
+	}
+	return nil
+}
+
+// mergeFile merges the user's edits of an existing file with the formatted generated content.
+// Without a recorded base, the generated content is written as <file>.new instead.
+func mergeFile(filePath, content, projectDir string) error {
+	path, ok := basePath(projectDir, filePath)
+	base, err := os.ReadFile(path)
+	if !ok || err != nil {
+		fmt.Printf("Warning: no generated version of %s recorded to merge with\n", filePath)
+		return writeNewFile(filePath, content, projectDir)
+	}
+
+	current, err := utils.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	generated, err := utils.FormatDart(projectDir, content)
+	if err != nil {
+		return err
+	}
+
+	// Merged files are not formatted again, which would fail on conflict markers
+	merged, conflicted := utils.MergeLines(string(base), string(current), generated)
+	if err := utils.WriteFile(filePath, []byte(merged)); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+	rememberBase(filePath, &generated)
+
+	if conflicted {
+		fmt.Printf("Warning: conflicts in %s, resolve the <<<<<<< markers\n", filePath)
+	}
+	return nil
+}
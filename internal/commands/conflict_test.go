@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"errors"
+	"flart/internal/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// regenerateUser generates the User model, edits it with edit, then generates it again with a
+// name field under policy
+func regenerateUser(t *testing.T, projectDir string, policy OverwritePolicy, edit func(string) string) error {
+	t.Helper()
+	t.Cleanup(func() { SetOverwritePolicy(OverwriteAsk) })
+
+	if err := Transaction(func() error { return CreateModel("User", ModelOptions{}) }); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	writeProjectFile(t, projectDir, "lib/models/user.dart", edit(readProjectFile(t, projectDir, "lib/models/user.dart")))
+
+	fields, err := utils.ParseFields("id:String,name:String")
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetOverwritePolicy(policy)
+	return Transaction(func() error { return CreateModel("User", ModelOptions{Fields: fields}) })
+}
+
+// addComment marks the model as edited by the user
+func addComment(content string) string {
+	return content + "\n// Edited by hand\n"
+}
+
+func TestConflictMergeKeepsEditsAndGeneratedChanges(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	if err := regenerateUser(t, projectDir, OverwriteMerge, addComment); err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+
+	content := readProjectFile(t, projectDir, "lib/models/user.dart")
+	if !strings.HasSuffix(content, "// Edited by hand\n") || !strings.Contains(content, "final String name;") {
+		t.Errorf("merged model lacks the edit or the new field:\n%s", content)
+	}
+	if strings.Contains(content, "<<<<<<<") {
+		t.Errorf("merged model has conflicts:\n%s", content)
+	}
+	// The merged generation is the base of the next one
+	base := readProjectFile(t, projectDir, ".dart_tool/flart/base/lib/models/user.dart")
+	if strings.Contains(base, "Edited by hand") || !strings.Contains(base, "final String name;") {
+		t.Errorf("recorded base is not the generated content:\n%s", base)
+	}
+}
+
+func TestConflictMergeMarksConflictingEdits(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	err := regenerateUser(t, projectDir, OverwriteMerge, func(content string) string {
+		return strings.Replace(content, "final String id;", "final String id; // The user id", 1)
+	})
+	if err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+
+	content := readProjectFile(t, projectDir, "lib/models/user.dart")
+	if !strings.Contains(content, "<<<<<<< current\n") || !strings.Contains(content, "// The user id") {
+		t.Errorf("merged model does not mark the conflict:\n%s", content)
+	}
+}
+
+func TestConflictMergeWithoutBaseWritesNewFile(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	err := regenerateUser(t, projectDir, OverwriteMerge, func(content string) string {
+		if err := os.RemoveAll(filepath.Join(projectDir, ".dart_tool", "flart", "base")); err != nil {
+			t.Fatal(err)
+		}
+		return addComment(content)
+	})
+	if err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	if content := readProjectFile(t, projectDir, "lib/models/user.dart"); !strings.HasSuffix(content, "// Edited by hand\n") {
+		t.Errorf("model was replaced without a base to merge with:\n%s", content)
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "lib/models/user.dart.new"), "final String name;") {
+		t.Errorf("generated version was not written as .new")
+	}
+}
+
+func TestConflictNewWritesNextToEditedFile(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	if err := regenerateUser(t, projectDir, OverwriteNew, addComment); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	content := readProjectFile(t, projectDir, "lib/models/user.dart")
+	if !strings.HasSuffix(content, "// Edited by hand\n") || strings.Contains(content, "final String name;") {
+		t.Errorf("existing model was changed:\n%s", content)
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "lib/models/user.dart.new"), "final String name;") {
+		t.Errorf("generated version was not written as .new")
+	}
+}
+
+func TestConflictNeverKeepsExistingFiles(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	err := regenerateUser(t, projectDir, OverwriteNever, addComment)
+	if !errors.Is(err, ErrKept) {
+		t.Fatalf("err = %v, want ErrKept", err)
+	}
+	if projectFileExists(projectDir, "lib/models/user.dart.new") {
+		t.Errorf("a .new file was written")
+	}
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CustomGenerator is an artifact type declared under generators in the config
+type CustomGenerator struct {
+	Name string
+	*config.GeneratorConfig
+}
+
+// CustomGenerators returns the generators declared in the config, sorted by name
+func CustomGenerators() ([]CustomGenerator, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	generators := make([]CustomGenerator, 0, len(cfg.Generators))
+	for name, generator := range cfg.Generators {
+		generators = append(generators, CustomGenerator{Name: name, GeneratorConfig: generator})
+	}
+	sort.Slice(generators, func(i, j int) bool { return generators[i].Name < generators[j].Name })
+	return generators, nil
+}
+
+// CreateCustom creates an artifact with the custom generator of the given name, writing each
+// of its files, adding its dependencies and exporting the files from its barrel
+func CreateCustom(generatorName, name string, fields []utils.Field) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	generator, ok := cfg.Generators[generatorName]
+	if !ok {
+		return fmt.Errorf("unknown generator %s", generatorName)
+	}
+	projectDir := *cfg.ProjectDir
+
+	var paths []string
+	files := map[string]string{}
+	for _, file := range generator.Files {
+		if !templates.HasTemplate(file.Template) {
+			return fmt.Errorf("template %s not found, create it as %s", file.Template,
+				filepath.Join(templates.OverrideDir, filepath.FromSlash(file.Template)))
+		}
+		path, err := customPath(projectDir, file.Path, name, fields)
+		if err != nil {
+			return err
+		}
+		if _, ok := files[path]; ok {
+			return fmt.Errorf("generator %s writes %s twice", generatorName, path)
+		}
+		content, err := templates.GenerateCustom(file.Template, name, fields)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+		files[path] = content
+	}
+
+	// Check existing files with user confirmation
+	if err := confirmOverwrite(paths...); err != nil {
+		return err
+	}
+
+	for _, dep := range generator.Dependencies {
+		if err := utils.AddDependency(dep, projectDir); err != nil {
+			return fmt.Errorf("failed to add %s dependency: %w", dep, err)
+		}
+	}
+	for _, dep := range generator.DevDependencies {
+		if err := utils.AddDevDependency(dep, projectDir); err != nil {
+			return fmt.Errorf("failed to add %s dependency: %w", dep, err)
+		}
+	}
+
+	for _, path := range paths {
+		if err := utils.MkdirAll(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+		}
+		if err := writeAndFormatFile(path, files[path], projectDir); err != nil {
+			return err
+		}
+	}
+
+	if generator.Barrel == "" {
+		return nil
+	}
+	barrel, err := customPath(projectDir, generator.Barrel, name, fields)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := addBarrelExport(barrel, path); err != nil {
+			return fmt.Errorf("failed to update barrel file: %w", err)
+		}
+	}
+	return nil
+}
+
+// customPath renders a path pattern of a custom generator, which must stay inside the project
+func customPath(projectDir, pattern, name string, fields []utils.Field) (string, error) {
+	rel, err := templates.CustomPath(pattern, name, fields)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return "", fmt.Errorf("path %s is outside of the project", rel)
+	}
+	return filepath.Join(projectDir, filepath.FromSlash(rel)), nil
+}
+
+// addBarrelExport exports a Dart file from a barrel in the same or a parent directory,
+// creating the barrel when needed. Other files, such as tests, are left out.
+func addBarrelExport(barrel, file string) error {
+	if file == barrel || filepath.Ext(file) != ".dart" {
+		return nil
+	}
+	rel, err := filepath.Rel(filepath.Dir(barrel), file)
+	if err != nil || !filepath.IsLocal(rel) {
+		return nil
+	}
+	exportLine := fmt.Sprintf("export '%s';", filepath.ToSlash(rel))
+
+	var content []byte
+	if utils.FileExists(barrel) {
+		if content, err = utils.ReadFile(barrel); err != nil {
+			return err
+		}
+	} else if err := utils.MkdirAll(filepath.Dir(barrel)); err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == exportLine {
+			return nil
+		}
+	}
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+	return utils.WriteFile(barrel, append(content, exportLine+"\n"...))
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"flart/internal/utils"
+	"os"
+	"strings"
+	"testing"
+)
+
+// dialogGenerator is a custom generator writing a dialog and its test
+const dialogGenerator = `{"generators": {"dialog": {
+	"files": [
+		{"path": "lib/dialogs/{{.Name.Snake}}_dialog.dart", "template": "dialog/dialog.dart.tmpl"},
+		{"path": "test/dialogs/{{.Name.Snake}}_dialog_test.dart", "template": "dialog/test.dart.tmpl"}
+	],
+	"barrel": "lib/dialogs/dialogs.dart",
+	"dependencies": ["flutter_animate"]
+}}}`
+
+// writeDialogTemplates writes the templates of dialogGenerator
+func writeDialogTemplates(t *testing.T, projectDir string) {
+	t.Helper()
+	writeProjectFile(t, projectDir, ".flart/templates/dialog/dialog.dart.tmpl",
+		"class {{.Name.Pascal}}Dialog {\n{{- range .Fields}}\n  final {{.Type}} {{camel .Name}};\n{{- end}}\n}\n")
+	writeProjectFile(t, projectDir, ".flart/templates/dialog/test.dart.tmpl",
+		"import 'package:{{.PackageName}}/dialogs/{{.Name.Snake}}_dialog.dart';\n")
+}
+
+func TestCustomGeneratorWritesFilesAndBarrel(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", dialogGenerator)
+	writeDialogTemplates(t, projectDir)
+	log := recordCommands(t)
+
+	fields, err := utils.ParseFields("title:String,message:String?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = CreateBatch([]string{"Confirm", "DeleteItem"}, func(name string) error {
+		return CreateCustom("dialog", name, fields)
+	})
+	if err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	// Templates drop their final newline, which dart format restores
+	files := map[string]string{
+		"lib/dialogs/confirm_dialog.dart":           "class ConfirmDialog {\n  final String title;\n  final String? message;\n}",
+		"test/dialogs/delete_item_dialog_test.dart": "import 'package:app/dialogs/delete_item_dialog.dart';",
+		"lib/dialogs/dialogs.dart":                  "export 'confirm_dialog.dart';\nexport 'delete_item_dialog.dart';\n",
+	}
+	for file, want := range files {
+		if content := readProjectFile(t, projectDir, file); content != want {
+			t.Errorf("%s = %q, want %q", file, content, want)
+		}
+	}
+
+	commands, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(commands), "flutter pub add flutter_animate\n") != 1 {
+		t.Errorf("dependency was not added once:\n%s", commands)
+	}
+}
+
+func TestCustomGeneratorRejectsPathsOutsideProject(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json",
+		strings.Replace(dialogGenerator, "lib/dialogs/{{.Name.Snake}}_dialog.dart", "../{{.Name.Snake}}_dialog.dart", 1))
+	writeDialogTemplates(t, projectDir)
+
+	err := Transaction(func() error { return CreateCustom("dialog", "Confirm", nil) })
+	if err == nil || !strings.Contains(err.Error(), "path ../confirm_dialog.dart is outside of the project") {
+		t.Fatalf("err = %v, want the path rejected", err)
+	}
+}
+
+func TestCustomGeneratorRequiresItsTemplates(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", dialogGenerator)
+
+	err := Transaction(func() error { return CreateCustom("dialog", "Confirm", nil) })
+	if err == nil || !strings.Contains(err.Error(), "template dialog/dialog.dart.tmpl not found") {
+		t.Fatalf("err = %v, want the missing template reported", err)
+	}
+	if projectFileExists(projectDir, "lib/dialogs") {
+		t.Errorf("files were written without their templates")
+	}
+}
@@ -0,0 +1,335 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DataSourceOptions holds the options accepted by make:datasource
+type DataSourceOptions struct {
+	Remote bool
+	Local  bool
+}
+
+// dataDirPath returns the absolute path of the configured data layer directory
+func dataDirPath(cfg *config.Config) string {
+	dir := "lib/data"
+	if cfg.Data != nil && cfg.Data.Dir != nil && *cfg.Data.Dir != "" {
+		dir = *cfg.Data.Dir
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(*cfg.ProjectDir, dir)
+}
+
+func CreateDataSource(name string, opts DataSourceOptions) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Generate both variants when none is requested
+	if !opts.Remote && !opts.Local {
+		opts.Remote = true
+		opts.Local = true
+	}
+
+	projectDir := *cfg.ProjectDir
+	dataDir := dataDirPath(cfg)
+	useHive := cfg.Data != nil && cfg.Data.LocalStorage != nil && *cfg.Data.LocalStorage == config.StorageHive
+
+	// Tests mirror the data directory's location under lib/
+	rel, err := filepath.Rel(filepath.Join(projectDir, "lib"), dataDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("data directory %s is not inside the lib directory", dataDir)
+	}
+	testDir := filepath.Join(projectDir, "test", rel)
+
+	packageName, err := utils.GetFlutterPackageName(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get package name: %w", err)
+	}
+	importPrefix := fmt.Sprintf("package:%s/%s", packageName, filepath.ToSlash(rel))
+
+	// Add dependencies
+	var dependencies []string
+	if opts.Remote {
+		dependencies = append(dependencies, "dio")
+	}
+	if opts.Local {
+		if useHive {
+			dependencies = append(dependencies, "hive")
+			// The service locator opens the box once Hive is initialized for Flutter
+			if diType(cfg) != config.DINone {
+				dependencies = append(dependencies, "hive_flutter")
+			}
+		} else {
+			dependencies = append(dependencies, "shared_preferences")
+		}
+	}
+	for _, dep := range dependencies {
+		if err := utils.AddDependency(dep, projectDir); err != nil {
+			return fmt.Errorf("failed to add dependency %s: %w", dep, err)
+		}
+	}
+	if err := utils.AddDevDependency("mocktail", projectDir); err != nil {
+		return fmt.Errorf("failed to add mocktail dependency: %w", err)
+	}
+
+	// Set up the service locator if DI is enabled
+	if _, err := setupInjection(cfg, packageName); err != nil {
+		return fmt.Errorf("failed to set up dependency injection: %w", err)
+	}
+	injectable := diType(cfg) == config.DIInjectable
+
+	pascalName := utils.ToPascalCase(name)
+	snakeCase := utils.ToSnakeCase(name)
+	files := map[string]string{}
+	var regs []registration
+	var instances []dataInstance
+	var exports []string
+
+	if opts.Remote {
+		file := filepath.Join(dataDir, snakeCase+"_remote_data_source.dart")
+		content, err := templates.GenerateRemoteDataSource(name, injectable)
+		if err != nil {
+			return err
+		}
+		test, err := templates.GenerateRemoteDataSourceTest(name, importPrefix)
+		if err != nil {
+			return err
+		}
+		files[file] = content
+		files[filepath.Join(testDir, snakeCase+"_remote_data_source_test.dart")] = test
+		regs = append(regs, registration{
+			className: pascalName + "RemoteDataSourceImpl",
+			asType:    pascalName + "RemoteDataSource",
+			file:      file,
+			lifetime:  lifetimeLazySingleton,
+			deps:      1,
+		})
+		instances = append(instances, dioInstance)
+		exports = append(exports, name+"RemoteDataSource")
+	}
+	if opts.Local {
+		file := filepath.Join(dataDir, snakeCase+"_local_data_source.dart")
+		content, err := templates.GenerateLocalDataSource(name, useHive, injectable)
+		if err != nil {
+			return err
+		}
+		test, err := templates.GenerateLocalDataSourceTest(name, importPrefix, useHive)
+		if err != nil {
+			return err
+		}
+		files[file] = content
+		files[filepath.Join(testDir, snakeCase+"_local_data_source_test.dart")] = test
+		regs = append(regs, registration{
+			className: pascalName + "LocalDataSourceImpl",
+			asType:    pascalName + "LocalDataSource",
+			file:      file,
+			lifetime:  lifetimeLazySingleton,
+			deps:      1,
+		})
+		if useHive {
+			instances = append(instances, hiveBoxInstance)
+		} else {
+			instances = append(instances, sharedPreferencesInstance)
+		}
+		exports = append(exports, name+"LocalDataSource")
+	}
+
+	// Typed exceptions are shared by every data source
+	exceptionsFile := filepath.Join(dataDir, "exceptions.dart")
+	if !utils.FileExists(exceptionsFile) {
+		content, err := templates.GenerateDataExceptions()
+		if err != nil {
+			return err
+		}
+		files[exceptionsFile] = content
+		exports = append(exports, "exceptions")
+	}
+
+	// Check existing files with user confirmation
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	if err := confirmOverwrite(paths...); err != nil {
+		return err
+	}
+
+	dirs := []string{dataDir, testDir}
+	for _, dir := range dirs {
+		if err := utils.MkdirAll(dir); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	for filePath, content := range files {
+		if err := writeAndFormatFile(filePath, content, projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Register the implementations with the service locator, along with the instances they receive
+	if err := register(cfg, packageName, regs...); err != nil {
+		return fmt.Errorf("failed to register dependencies: %w", err)
+	}
+	if err := provideDataInstances(cfg, dataDir, instances); err != nil {
+		return fmt.Errorf("failed to register data source dependencies: %w", err)
+	}
+
+	if injectable {
+		if err := runBuildRunner(projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Update barrel file
+	barrelName := filepath.Base(dataDir) + ".dart"
+	for _, export := range exports {
+		if err := utils.UpdateBarrelFile(dataDir, export, barrelName); err != nil {
+			return fmt.Errorf("failed to update barrel file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// dataInstance is a third-party instance generated data sources receive from the service locator
+type dataInstance struct {
+	importLine string
+	typeName   string
+	// getter names the instance in the injectable module
+	getter string
+	// create is the Dart expression building the instance, a Future when async is set
+	create string
+	async  bool
+}
+
+var (
+	dioInstance = dataInstance{
+		importLine: "import 'package:dio/dio.dart';",
+		typeName:   "Dio",
+		getter:     "dio",
+		create:     "Dio()",
+	}
+	sharedPreferencesInstance = dataInstance{
+		importLine: "import 'package:shared_preferences/shared_preferences.dart';",
+		typeName:   "SharedPreferences",
+		getter:     "sharedPreferences",
+		create:     "SharedPreferences.getInstance()",
+		async:      true,
+	}
+	hiveBoxInstance = dataInstance{
+		importLine: "import 'package:hive_flutter/hive_flutter.dart';",
+		typeName:   "Box<dynamic>",
+		getter:     "box",
+		create:     "Hive.initFlutter().then((_) => Hive.openBox<dynamic>('app'))",
+		async:      true,
+	}
+)
+
+// provideDataInstances makes the instances available from the service locator: get_it projects
+// register them in the injection file, injectable projects get them from a module in the data
+// directory. Async instances make configureDependencies async so that they are ready once it
+// is awaited.
+func provideDataInstances(cfg *config.Config, dataDir string, instances []dataInstance) error {
+	kind := diType(cfg)
+	if kind == config.DINone || len(instances) == 0 {
+		return nil
+	}
+
+	projectDir := *cfg.ProjectDir
+	injectionFile := injectionFilePath(cfg)
+	async := false
+	for _, instance := range instances {
+		async = async || instance.async
+	}
+
+	if kind == config.DIGetIt {
+		for _, instance := range instances {
+			line := fmt.Sprintf("sl.registerLazySingleton<%s>(() => %s);", instance.typeName, instance.create)
+			if instance.async {
+				line = fmt.Sprintf("sl.registerSingletonAsync<%s>(() => %s);", instance.typeName, instance.create)
+			}
+			if err := utils.AddRegistration(injectionFile, instance.importLine, instance.typeName, line); err != nil {
+				return fmt.Errorf("failed to register %s: %w", instance.typeName, err)
+			}
+		}
+		if async {
+			if err := awaitInjection(injectionFile,
+				"void configureDependencies() {",
+				"Future<void> configureDependencies() async {",
+				"await sl.allReady();"); err != nil {
+				return err
+			}
+		}
+		return formatFile(injectionFile, projectDir)
+	}
+
+	moduleFile := filepath.Join(dataDir, "data_module.dart")
+	if !utils.FileExists(moduleFile) {
+		content, err := templates.GenerateDataModule()
+		if err != nil {
+			return err
+		}
+		if err := utils.WriteFile(moduleFile, []byte(content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", moduleFile, err)
+		}
+	}
+	for _, instance := range instances {
+		content, err := utils.ReadFile(moduleFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", moduleFile, err)
+		}
+		if strings.Contains(string(content), " get "+instance.getter+" ") {
+			continue
+		}
+
+		member := fmt.Sprintf("  @lazySingleton\n  %s get %s => %s;", instance.typeName, instance.getter, instance.create)
+		if instance.async {
+			member = fmt.Sprintf("  @preResolve\n  Future<%s> get %s => %s;", instance.typeName, instance.getter, instance.create)
+		}
+		if err := utils.AddClassMember(moduleFile, "DataModule", member, []string{instance.importLine}); err != nil {
+			return err
+		}
+	}
+	if err := formatFile(moduleFile, projectDir); err != nil {
+		return err
+	}
+
+	// Pre-resolved instances make the generated init function return a Future
+	if async {
+		return awaitInjection(injectionFile,
+			"void configureDependencies() => sl.init();",
+			"Future<void> configureDependencies() => sl.init();",
+			"")
+	}
+	return nil
+}
+
+// awaitInjection turns the configureDependencies declaration generated by setupInjection into
+// its async form, adding the statement waiting for async registrations below the marker.
+// Injection files declaring it differently are left as they are.
+func awaitInjection(injectionFile, declaration, asyncDeclaration, statement string) error {
+	content, err := utils.ReadFile(injectionFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", injectionFile, err)
+	}
+	text := string(content)
+	if !strings.Contains(text, declaration) {
+		return nil
+	}
+
+	text = strings.Replace(text, declaration, asyncDeclaration, 1)
+	if statement != "" {
+		text = strings.Replace(text, utils.InjectionMarker, utils.InjectionMarker+"\n  "+statement, 1)
+	}
+	return utils.WriteFile(injectionFile, []byte(text))
+}
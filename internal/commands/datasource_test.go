@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDataSourceDependenciesAreProvided(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		file   string
+		want   []string
+	}{
+		{
+			name:   "get_it with hive",
+			config: `{"di": {"type": "get_it"}, "data": {"localStorage": "hive"}}`,
+			file:   "lib/injection.dart",
+			want: []string{
+				"import 'package:dio/dio.dart';",
+				"import 'package:hive_flutter/hive_flutter.dart';",
+				"sl.registerLazySingleton<Dio>(() => Dio());",
+				"sl.registerSingletonAsync<Box<dynamic>>(() => Hive.initFlutter().then((_) => Hive.openBox<dynamic>('app')));",
+				"Future<void> configureDependencies() async {",
+				"await sl.allReady();",
+			},
+		},
+		{
+			name:   "injectable with shared_preferences",
+			config: `{"di": {"type": "injectable"}}`,
+			file:   "lib/data/data_module.dart",
+			want: []string{
+				"import 'package:shared_preferences/shared_preferences.dart';",
+				"@module",
+				"Dio get dio => Dio();",
+				"@preResolve\n  Future<SharedPreferences> get sharedPreferences => SharedPreferences.getInstance();",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := newTestProject(t)
+			if err := os.WriteFile(filepath.Join(projectDir, "flart_config.json"), []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := Transaction(func() error {
+				if err := CreateDataSource("User", DataSourceOptions{}); err != nil {
+					return err
+				}
+				// A second data source reuses the registered instances
+				return CreateDataSource("Order", DataSourceOptions{})
+			})
+			if err != nil {
+				t.Fatalf("generation failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(tt.file)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if n := strings.Count(string(content), want); n != 1 {
+					t.Errorf("%s contains %q %d times:\n%s", tt.file, want, n, content)
+				}
+			}
+		})
+	}
+}
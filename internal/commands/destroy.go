@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/utils"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DestroyScreen deletes a screen with its bloc or cubit and tests, and removes its export,
+// route and service locator registration
+func DestroyScreen(screenName string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	projectDir := *cfg.ProjectDir
+
+	snakeCase := utils.ToSnakeCase(screenName)
+	screenDir := filepath.Join(projectDir, "lib", "screens", snakeCase)
+	if info, err := os.Stat(screenDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("screen %s not found at %s", utils.ToPascalCase(screenName), screenDir)
+	}
+
+	packageName, err := utils.GetFlutterPackageName(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get package name: %w", err)
+	}
+
+	files, dirs, err := filesUnder(screenDir, filepath.Join(projectDir, "test", "screens", snakeCase))
+	if err != nil {
+		return err
+	}
+
+	err = Transaction(func() error {
+		if err := removeFiles(files); err != nil {
+			return err
+		}
+
+		exportLine := fmt.Sprintf("export '%s/%s.dart';", snakeCase, snakeCase)
+		if err := removeExport(filepath.Join(projectDir, "lib", "screens", "screens.dart"), exportLine); err != nil {
+			return err
+		}
+
+		// Drop the go_router route and the get_it registration of the bloc or cubit. Injectable
+		// registrations are generated, so build_runner drops them.
+		if routerType(cfg) == config.RouterGoRouter {
+			route := utils.ToCamelCase(screenName) + "Route,"
+			if err := removeReferences(projectDir, packageName, routerFilePath(cfg), files, route); err != nil {
+				return err
+			}
+		}
+		switch diType(cfg) {
+		case config.DIGetIt:
+			pascalCase := utils.ToPascalCase(screenName)
+			if err := removeReferences(projectDir, packageName, injectionFilePath(cfg), files,
+				"<"+pascalCase+"Bloc>(", "<"+pascalCase+"Cubit>("); err != nil {
+				return err
+			}
+		case config.DIInjectable:
+			if err := runBuildRunner(projectDir); err != nil {
+				return err
+			}
+		}
+
+		return warnImporters(projectDir, packageName, utils.ToPascalCase(screenName), files)
+	})
+	if err != nil {
+		return err
+	}
+
+	return removeEmptyDirs(dirs)
+}
+
+// DestroyModel deletes a model with its generated parts and test, and removes its export
+func DestroyModel(modelName string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	projectDir := *cfg.ProjectDir
+
+	snakeCase := utils.ToSnakeCase(modelName)
+	modelDir := filepath.Join(projectDir, "lib", "models")
+	modelFile := filepath.Join(modelDir, snakeCase+".dart")
+	if !utils.FileExists(modelFile) {
+		return fmt.Errorf("model %s not found at %s", utils.ToPascalCase(modelName), modelFile)
+	}
+
+	packageName, err := utils.GetFlutterPackageName(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get package name: %w", err)
+	}
+
+	var files []string
+	for _, file := range []string{
+		modelFile,
+		filepath.Join(modelDir, snakeCase+".g.dart"),
+		filepath.Join(modelDir, snakeCase+".freezed.dart"),
+		filepath.Join(projectDir, "test", "models", snakeCase+"_test.dart"),
+	} {
+		if utils.FileExists(file) {
+			files = append(files, file)
+		}
+	}
+
+	return Transaction(func() error {
+		if err := removeFiles(files); err != nil {
+			return err
+		}
+
+		exportLine := fmt.Sprintf("export '%s.dart';", snakeCase)
+		if err := removeExport(filepath.Join(modelDir, "models.dart"), exportLine); err != nil {
+			return err
+		}
+
+		return warnImporters(projectDir, packageName, utils.ToPascalCase(modelName), files)
+	})
+}
+
+// filesUnder returns the files below the given directories and the directories themselves, parents first.
+// Missing directories are skipped.
+func filesUnder(roots ...string) ([]string, []string, error) {
+	var files, dirs []string
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if entry.IsDir() {
+				dirs = append(dirs, path)
+			} else {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list %s: %w", root, err)
+		}
+	}
+	return files, dirs, nil
+}
+
+// removeFiles deletes each of files
+func removeFiles(files []string) error {
+	for _, file := range files {
+		if err := utils.RemoveFile(file); err != nil {
+			return fmt.Errorf("failed to remove file %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// removeExport removes an export line from a barrel file
+func removeExport(barrelPath, exportLine string) error {
+	err := utils.RemoveLines(barrelPath, func(line string) bool { return line == exportLine })
+	if err != nil {
+		return fmt.Errorf("failed to update barrel file: %w", err)
+	}
+	return nil
+}
+
+// removeReferences removes from file the imports of removed files and the whole statements or
+// list elements containing any of snippets, then formats it
+func removeReferences(projectDir, packageName, file string, removed []string, snippets ...string) error {
+	removedFiles := map[string]bool{}
+	for _, path := range removed {
+		removedFiles[path] = true
+	}
+
+	err := utils.RemoveLines(file, func(line string) bool {
+		if uri, ok := strings.CutPrefix(line, "import '"); ok {
+			uri, _, _ = strings.Cut(uri, "'")
+			return removedFiles[utils.ResolveImport(projectDir, packageName, file, uri)]
+		}
+		return false
+	})
+	if err == nil {
+		err = utils.RemoveStatements(file, snippets...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", file, err)
+	}
+
+	if !utils.FileExists(file) {
+		return nil
+	}
+	return formatFile(file, projectDir)
+}
+
+// warnImporters warns about project files that still import one of the removed files
+func warnImporters(projectDir, packageName, name string, removed []string) error {
+	importers, err := utils.FindImporters(projectDir, packageName, removed)
+	if err != nil {
+		return fmt.Errorf("failed to look for imports of %s: %w", name, err)
+	}
+
+	for _, importer := range importers {
+		if rel, err := filepath.Rel(projectDir, importer); err == nil {
+			importer = rel
+		}
+		fmt.Printf("Warning: %s still imports %s\n", filepath.ToSlash(importer), name)
+	}
+	return nil
+}
+
+// removeEmptyDirs removes the directories left empty by a destroy, deepest first
+func removeEmptyDirs(dirs []string) error {
+	if utils.DryRun() {
+		return nil
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		entries, err := os.ReadDir(dirs[i])
+		if err != nil || len(entries) > 0 {
+			continue
+		}
+		if err := os.Remove(dirs[i]); err != nil {
+			return fmt.Errorf("failed to remove directory %s: %w", dirs[i], err)
+		}
+	}
+	return nil
+}
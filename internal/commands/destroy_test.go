@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDestroyScreenRemovesWrappedReferences(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", `{"di": {"type": "get_it"}, "router": {"type": "go_router"}}`)
+	writeProjectFile(t, projectDir, "lib/screens/home/home.dart", "class HomeScreen {}\n")
+	writeProjectFile(t, projectDir, "lib/screens/home/bloc/home_bloc.dart", "class HomeBloc {}\n")
+	writeProjectFile(t, projectDir, "lib/injection.dart", `import 'package:get_it/get_it.dart';
+import 'package:app/screens/home/bloc/home_bloc.dart';
+
+final sl = GetIt.instance;
+
+void setupInjection() {
+  sl.registerFactory<HomeBloc>(
+    () => HomeBloc(
+      repository: sl(),
+      onDone: (value) {
+        print('done; ok, (really)');
+      },
+    ),
+  );
+  // sl.registerFactory<HomeBloc>(() => HomeBloc());
+  sl.registerFactory<AboutBloc>(() => AboutBloc());
+  // flart:registrations
+}
+`)
+	writeProjectFile(t, projectDir, "lib/router.dart", `import 'package:go_router/go_router.dart';
+import 'package:app/screens/home/home.dart';
+
+final router = GoRouter(
+  routes: [
+    homeRoute,
+    aboutRoute,
+    // flart:routes
+  ],
+);
+`)
+
+	if err := DestroyScreen("Home"); err != nil {
+		t.Fatalf("destroy failed: %v", err)
+	}
+
+	injection := readProjectFile(t, projectDir, "lib/injection.dart")
+	wantInjection := `import 'package:get_it/get_it.dart';
+
+final sl = GetIt.instance;
+
+void setupInjection() {
+  // sl.registerFactory<HomeBloc>(() => HomeBloc());
+  sl.registerFactory<AboutBloc>(() => AboutBloc());
+  // flart:registrations
+}
+`
+	if injection != wantInjection {
+		t.Errorf("injection file =\n%s\nwant\n%s", injection, wantInjection)
+	}
+
+	router := readProjectFile(t, projectDir, "lib/router.dart")
+	if strings.Contains(router, "home") || !strings.Contains(router, "    aboutRoute,\n    // flart:routes\n") {
+		t.Errorf("router still references the screen:\n%s", router)
+	}
+	if projectFileExists(projectDir, "lib/screens/home") {
+		t.Errorf("the screen directory was not removed")
+	}
+}
+
+func TestDestroyScreenRebuildsInjectableRegistrations(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", `{"di": {"type": "injectable"}}`)
+	writeProjectFile(t, projectDir, "lib/screens/home/home.dart", "class HomeScreen {}\n")
+	log := recordCommands(t)
+
+	if err := DestroyScreen("Home"); err != nil {
+		t.Fatalf("destroy failed: %v", err)
+	}
+
+	commands, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(commands), "dart run build_runner build") {
+		t.Errorf("build_runner did not run after destroy:\n%s", commands)
+	}
+}
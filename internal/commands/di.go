@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Service locator lifetimes used for get_it registrations
+const (
+	lifetimeFactory       = "registerFactory"
+	lifetimeLazySingleton = "registerLazySingleton"
+)
+
+// registration describes a generated class that should be available from the service locator
+type registration struct {
+	className string
+	asType    string
+	file      string
+	lifetime  string
+	deps      int
+}
+
+// diType returns the configured DI integration, defaulting to none
+func diType(cfg *config.Config) string {
+	if cfg.DI == nil || cfg.DI.Type == nil {
+		return config.DINone
+	}
+	return *cfg.DI.Type
+}
+
+// injectionFilePath returns the absolute path of the configured injection file
+func injectionFilePath(cfg *config.Config) string {
+	file := "lib/injection.dart"
+	if cfg.DI != nil && cfg.DI.InjectionFile != nil && *cfg.DI.InjectionFile != "" {
+		file = *cfg.DI.InjectionFile
+	}
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(*cfg.ProjectDir, file)
+}
+
+// setupInjection adds the DI dependencies and creates the injection file if needed.
+// It returns the import line generated code uses to reach the service locator.
+func setupInjection(cfg *config.Config, packageName string) (string, error) {
+	kind := diType(cfg)
+	if kind == config.DINone {
+		return "", nil
+	}
+
+	projectDir := *cfg.ProjectDir
+	if err := utils.AddDependency("get_it", projectDir); err != nil {
+		return "", fmt.Errorf("failed to add get_it dependency: %w", err)
+	}
+
+	if kind == config.DIInjectable {
+		if err := utils.AddDependency("injectable", projectDir); err != nil {
+			return "", fmt.Errorf("failed to add injectable dependency: %w", err)
+		}
+		for _, dep := range []string{"injectable_generator", "build_runner"} {
+			if err := utils.AddDevDependency(dep, projectDir); err != nil {
+				return "", fmt.Errorf("failed to add %s dependency: %w", dep, err)
+			}
+		}
+	}
+
+	injectionFile := injectionFilePath(cfg)
+	if !utils.FileExists(injectionFile) {
+		if err := utils.MkdirAll(filepath.Dir(injectionFile)); err != nil {
+			return "", fmt.Errorf("failed to create directory %s: %w", filepath.Dir(injectionFile), err)
+		}
+		content, err := templates.GenerateInjection(kind == config.DIInjectable, filepath.Base(injectionFile))
+		if err != nil {
+			return "", err
+		}
+		if err := writeAndFormatFile(injectionFile, content, projectDir); err != nil {
+			return "", err
+		}
+	}
+
+	return utils.PackageImport(projectDir, packageName, injectionFile)
+}
+
+// register adds get_it registrations to the injection file.
+// Injectable projects rely on annotations instead, so nothing is written for them.
+func register(cfg *config.Config, packageName string, regs ...registration) error {
+	if diType(cfg) != config.DIGetIt {
+		return nil
+	}
+
+	injectionFile := injectionFilePath(cfg)
+	for _, reg := range regs {
+		importLine, err := utils.PackageImport(*cfg.ProjectDir, packageName, reg.file)
+		if err != nil {
+			return err
+		}
+
+		// Register implementations under their abstract type when one is given
+		asType := reg.className
+		if reg.asType != "" {
+			asType = reg.asType
+		}
+
+		args := strings.TrimSuffix(strings.Repeat("sl(), ", reg.deps), ", ")
+		line := fmt.Sprintf("sl.%s<%s>(() => %s(%s));", reg.lifetime, asType, reg.className, args)
+		if err := utils.AddRegistration(injectionFile, importLine, asType, line); err != nil {
+			return fmt.Errorf("failed to register %s: %w", reg.className, err)
+		}
+	}
+
+	return formatFile(injectionFile, *cfg.ProjectDir)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegisterSkipsRegistrationsWrappedByFormat(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", `{"di": {"type": "get_it"}}`)
+	writeProjectFile(t, projectDir, "lib/usecases/get_user_profile.dart", "class GetUserProfile {}\n")
+	// dart format wraps registrations longer than 80 characters
+	writeProjectFile(t, projectDir, "lib/injection.dart", `import 'package:get_it/get_it.dart';
+import 'package:app/usecases/get_user_profile.dart';
+
+final sl = GetIt.instance;
+
+void configureDependencies() {
+  sl.registerLazySingleton<GetUserProfile>(
+    () => GetUserProfile(sl()),
+  );
+  // flart:registrations
+}
+`)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = register(cfg, "app", registration{
+		className: "GetUserProfile",
+		file:      filepath.Join(projectDir, "lib", "usecases", "get_user_profile.dart"),
+		lifetime:  lifetimeLazySingleton,
+		deps:      1,
+	})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	content := readProjectFile(t, projectDir, "lib/injection.dart")
+	if n := strings.Count(content, "<GetUserProfile>("); n != 1 {
+		t.Errorf("GetUserProfile is registered %d times:\n%s", n, content)
+	}
+}
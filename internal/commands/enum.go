@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+)
+
+// CreateEnum generates an enum next to the models so that models can use it as a field type
+func CreateEnum(name string, values []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(values) == 0 {
+		return fmt.Errorf("enum %s needs at least one value", name)
+	}
+
+	projectDir := *cfg.ProjectDir
+	modelDir := filepath.Join(projectDir, "lib", "models")
+	enumFile := filepath.Join(modelDir, utils.ToSnakeCase(name)+".dart")
+
+	// Check existing files with user confirmation
+	if err := confirmOverwrite(enumFile); err != nil {
+		return err
+	}
+
+	if err := utils.MkdirAll(modelDir); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", modelDir, err)
+	}
+
+	content, err := templates.GenerateEnum(name, values)
+	if err != nil {
+		return err
+	}
+	if err := writeAndFormatFile(enumFile, content, projectDir); err != nil {
+		return err
+	}
+
+	// Update barrel file
+	if err := utils.UpdateBarrelFile(modelDir, name, "models.dart"); err != nil {
+		return fmt.Errorf("failed to update barrel file: %w", err)
+	}
+
+	return nil
+}
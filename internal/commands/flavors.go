@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// flavorConfigDir holds the per-flavor dart define files, relative to the project
+const flavorConfigDir = "config"
+
+// flavorPattern matches flavor names usable as Dart enum values and file names
+var flavorPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// CreateFlavors generates flavor entrypoints, AppConfig and define files, and stores the flavor list.
+// Without flavors, the list stored in the config is regenerated.
+func CreateFlavors(flavors []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(flavors) == 0 {
+		flavors = cfg.Flavors
+	}
+	if len(flavors) == 0 {
+		return fmt.Errorf("no flavors given and none stored in the config")
+	}
+
+	seen := map[string]bool{}
+	for _, flavor := range flavors {
+		if !flavorPattern.MatchString(flavor) {
+			return fmt.Errorf("invalid flavor %q, expected lowercase letters, digits and underscores", flavor)
+		}
+		if seen[flavor] {
+			return fmt.Errorf("flavor %s is listed more than once", flavor)
+		}
+		seen[flavor] = true
+	}
+
+	projectDir := *cfg.ProjectDir
+	libDir := filepath.Join(projectDir, "lib")
+	if !utils.FileExists(filepath.Join(libDir, "main.dart")) {
+		return fmt.Errorf("flavor entrypoints wrap lib/main.dart, which was not found")
+	}
+
+	packageName, err := utils.GetFlutterPackageName(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get package name: %w", err)
+	}
+
+	appConfig, err := templates.GenerateAppConfig(flavors)
+	if err != nil {
+		return err
+	}
+	files := map[string]string{
+		filepath.Join(libDir, "config", "app_config.dart"): appConfig,
+	}
+	for _, flavor := range flavors {
+		content, err := templates.GenerateFlavorMain(flavor, packageName)
+		if err != nil {
+			return err
+		}
+		files[filepath.Join(libDir, "main_"+flavor+".dart")] = content
+	}
+
+	// Check existing files with user confirmation
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+	if err := confirmOverwrite(paths...); err != nil {
+		return err
+	}
+
+	// The files and the stored flavor list are written together or not at all
+	return Transaction(func() error {
+		dirs := []string{filepath.Join(libDir, "config"), filepath.Join(projectDir, flavorConfigDir)}
+		for _, dir := range dirs {
+			if err := utils.MkdirAll(dir); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", dir, err)
+			}
+		}
+
+		for _, filePath := range paths {
+			if err := writeAndFormatFile(filePath, files[filePath], projectDir); err != nil {
+				return err
+			}
+		}
+
+		// Define files usually hold edited values, so existing ones are kept
+		appName := appDisplayName(packageName)
+		for _, flavor := range flavors {
+			envFile := filepath.Join(projectDir, flavorConfigDir, flavor+".json")
+			if utils.FileExists(envFile) {
+				fmt.Printf("Keeping existing %s\n", filepath.Join(flavorConfigDir, flavor+".json"))
+				continue
+			}
+
+			content, err := templates.GenerateFlavorEnv(flavor, appName)
+			if err != nil {
+				return err
+			}
+			if err := utils.WriteFile(envFile, []byte(content)); err != nil {
+				return fmt.Errorf("failed to write file %s: %w", envFile, err)
+			}
+		}
+
+		if err := config.SetFlavors(flavors); err != nil {
+			return fmt.Errorf("failed to store flavors: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// appDisplayName turns a package name like my_app into My App
+func appDisplayName(packageName string) string {
+	words := strings.Split(packageName, "_")
+	for i, word := range words {
+		words[i] = utils.ToPascalCase(word)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// newTestProject creates a Flutter project without lib/models or lib/screens, makes it the
+// working directory and puts no-op dart and flutter commands first in PATH
+func newTestProject(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake dart and flutter commands are shell scripts")
+	}
+
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectDir, "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	pubspec := "name: app\n\ndependencies:\n  flutter:\n    sdk: flutter\n"
+	if err := os.WriteFile(filepath.Join(projectDir, "pubspec.yaml"), []byte(pubspec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bin := t.TempDir()
+	for _, name := range []string{"dart", "flutter"} {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return projectDir
+}
+
+// writeProjectFile writes content to a file of the project, creating its directory
+func writeProjectFile(t *testing.T, projectDir, file, content string) {
+	t.Helper()
+	path := filepath.Join(projectDir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readProjectFile returns the content of a file of the project
+func readProjectFile(t *testing.T, projectDir, file string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(file)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// projectFileExists reports whether a file of the project exists
+func projectFileExists(projectDir, file string) bool {
+	_, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(file)))
+	return err == nil
+}
+
+// recordCommands replaces the fake dart and flutter commands by ones logging their arguments,
+// and returns the log file
+func recordCommands(t *testing.T) string {
+	t.Helper()
+	bin := t.TempDir()
+	log := filepath.Join(bin, "commands.log")
+	for _, name := range []string{"dart", "flutter"} {
+		script := "#!/bin/sh\necho \"" + name + " $*\" >> " + log + "\n"
+		if err := os.WriteFile(filepath.Join(bin, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
@@ -0,0 +1,252 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flart/internal/config"
+	"flart/internal/utils"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// historyDir holds one journal file per generation, relative to the project
+var historyDir = filepath.Join(".dart_tool", "flart", "history")
+
+// historyEntry is the journal of one generation
+type historyEntry struct {
+	// id is the journal file name without extension, ordered by time
+	id      string
+	Command string        `json:"command"`
+	Time    time.Time     `json:"time"`
+	Files   []historyFile `json:"files"`
+	// Dirs are the directories created by the generation, parents first
+	Dirs []string `json:"dirs,omitempty"`
+}
+
+// historyFile is a file changed by a generation, with paths relative to the project
+type historyFile struct {
+	Path string `json:"path"`
+	// Before is the previous content, nil for a created file
+	Before *string `json:"before"`
+	// After is the SHA-256 of the generated content, empty when the generation removed the file
+	After string `json:"after,omitempty"`
+}
+
+// contentHash returns the hex SHA-256 of content
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// invokedCommand describes how flart was run, for the history
+func invokedCommand() string {
+	if len(os.Args) <= 1 {
+		return "flart (interactive)"
+	}
+	return strings.Join(append([]string{"flart"}, os.Args[1:]...), " ")
+}
+
+// recordHistory writes the journal of a generation so that it can be undone
+func recordHistory(projectDir string, changes *utils.Changes) error {
+	if changes == nil || len(changes.Files) == 0 {
+		return nil
+	}
+
+	entry := historyEntry{Command: invokedCommand(), Time: time.Now()}
+	for _, change := range changes.Files {
+		rel, err := filepath.Rel(projectDir, change.Path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("%s is outside of the project", change.Path)
+		}
+
+		file := historyFile{Path: filepath.ToSlash(rel), Before: change.Before}
+		if change.After != nil {
+			file.After = contentHash(*change.After)
+		}
+		entry.Files = append(entry.Files, file)
+	}
+	for _, dir := range changes.Dirs {
+		if rel, err := filepath.Rel(projectDir, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			entry.Dirs = append(entry.Dirs, filepath.ToSlash(rel))
+		}
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+
+	dir := filepath.Join(projectDir, historyDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	file := filepath.Join(dir, fmt.Sprintf("%d.json", entry.Time.UnixNano()))
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write history %s: %w", file, err)
+	}
+	return nil
+}
+
+// loadHistory returns the journals of the project, oldest first
+func loadHistory(projectDir string) ([]historyEntry, error) {
+	dir := filepath.Join(projectDir, historyDir)
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+
+	var entries []historyEntry
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history %s: %w", file, err)
+		}
+
+		var entry historyEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse history %s: %w", file, err)
+		}
+		entry.id = strings.TrimSuffix(filepath.Base(file), ".json")
+		entries = append(entries, entry)
+	}
+
+	// Ids are nanosecond timestamps, so longer ids are later
+	sort.Slice(entries, func(i, j int) bool {
+		if len(entries[i].id) != len(entries[j].id) {
+			return len(entries[i].id) < len(entries[j].id)
+		}
+		return entries[i].id < entries[j].id
+	})
+	return entries, nil
+}
+
+// History lists the recorded generations, most recent first
+func History() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	entries, err := loadHistory(*cfg.ProjectDir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No generations recorded")
+		return nil
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		fmt.Printf("%3d  %s  %s\n", i+1, entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Command)
+		for _, file := range entry.Files {
+			fmt.Printf("       %s %s\n", historyAction(file), file.Path)
+		}
+	}
+	return nil
+}
+
+// historyAction describes what a generation did to a file
+func historyAction(file historyFile) string {
+	switch {
+	case file.Before == nil:
+		return "created "
+	case file.After == "":
+		return "removed "
+	default:
+		return "modified"
+	}
+}
+
+// editedFiles returns the files of an entry whose content changed since the generation
+func editedFiles(projectDir string, entry historyEntry) []string {
+	var edited []string
+	for _, file := range entry.Files {
+		current := ""
+		if content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(file.Path))); err == nil {
+			current = contentHash(string(content))
+		}
+		if current != file.After {
+			edited = append(edited, file.Path)
+		}
+	}
+	return edited
+}
+
+// Undo reverts the most recent generation, refusing when its files were edited since unless forced
+func Undo(force bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	projectDir := *cfg.ProjectDir
+
+	entries, err := loadHistory(projectDir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("nothing to undo")
+	}
+	entry := entries[len(entries)-1]
+
+	if edited := editedFiles(projectDir, entry); len(edited) > 0 && !force {
+		return fmt.Errorf("files changed since %q: %s, use --force to undo anyway",
+			entry.Command, strings.Join(edited, ", "))
+	}
+
+	// Revert as one transaction, so that a failure leaves the files as they were
+	started := utils.BeginTransaction()
+	for i := len(entry.Files) - 1; i >= 0; i-- {
+		file := entry.Files[i]
+		path := filepath.Join(projectDir, filepath.FromSlash(file.Path))
+
+		if file.Before != nil {
+			err = utils.WriteFile(path, []byte(*file.Before))
+		} else if utils.FileExists(path) {
+			err = utils.RemoveFile(path)
+		}
+		if err != nil {
+			if started {
+				utils.EndTransaction()
+			}
+			return fmt.Errorf("failed to revert %s: %w", file.Path, err)
+		}
+	}
+
+	if utils.DryRun() {
+		return nil
+	}
+
+	if err := utils.ApplyTransaction(); err != nil {
+		if _, rollbackErr := utils.RollbackTransaction(); rollbackErr != nil {
+			return fmt.Errorf("%w, and %w", err, rollbackErr)
+		}
+		return err
+	}
+	utils.EndTransaction()
+
+	// Directories created by the generation are removed once empty, deepest first
+	for i := len(entry.Dirs) - 1; i >= 0; i-- {
+		os.Remove(filepath.Join(projectDir, filepath.FromSlash(entry.Dirs[i])))
+	}
+
+	if err := os.Remove(filepath.Join(projectDir, historyDir, entry.id+".json")); err != nil {
+		return fmt.Errorf("failed to remove history %s: %w", entry.id, err)
+	}
+
+	fmt.Printf("Undid %s\n", entry.Command)
+	for _, file := range entry.Files {
+		action := "restored"
+		if file.Before == nil {
+			action = "removed"
+		}
+		fmt.Printf("  %s %s\n", action, file.Path)
+	}
+	return nil
+}
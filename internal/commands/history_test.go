@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHistoryRecordsGeneration(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "lib/models/models.dart", "export 'post.dart';\n")
+
+	if err := Transaction(func() error { return CreateModel("User", ModelOptions{}) }); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	entries, err := loadHistory(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d history entries, want 1", len(entries))
+	}
+
+	actions := map[string]string{}
+	for _, file := range entries[0].Files {
+		actions[file.Path] = strings.TrimSpace(historyAction(file))
+	}
+	want := map[string]string{
+		"lib/models/user.dart":       "created",
+		"test/models/user_test.dart": "created",
+		"lib/models/models.dart":     "modified",
+	}
+	for path, action := range want {
+		if actions[path] != action {
+			t.Errorf("history of %s = %q, want %q (all: %v)", path, actions[path], action, actions)
+		}
+	}
+}
+
+func TestUndoRevertsGeneration(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "lib/models/models.dart", "export 'post.dart';\n")
+
+	if err := Transaction(func() error { return CreateModel("User", ModelOptions{}) }); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	if err := Undo(false); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+
+	for _, file := range []string{"lib/models/user.dart", "test/models/user_test.dart"} {
+		if projectFileExists(projectDir, file) {
+			t.Errorf("undo left %s", file)
+		}
+	}
+	if got := readProjectFile(t, projectDir, "lib/models/models.dart"); got != "export 'post.dart';\n" {
+		t.Errorf("barrel = %q, want the content before the generation", got)
+	}
+	if projectFileExists(projectDir, "test/models") {
+		t.Errorf("undo left the test/models directory it created")
+	}
+
+	entries, err := loadHistory(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("undo left %d history entries", len(entries))
+	}
+	if err := Undo(false); err == nil || err.Error() != "nothing to undo" {
+		t.Errorf("second undo err = %v, want nothing to undo", err)
+	}
+}
+
+func TestUndoRefusesEditedFilesUnlessForced(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	if err := Transaction(func() error { return CreateModel("User", ModelOptions{}) }); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	writeProjectFile(t, projectDir, "lib/models/user.dart", "// Edited by hand\n")
+
+	err := Undo(false)
+	if err == nil || !strings.Contains(err.Error(), "lib/models/user.dart") || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("err = %v, want the edited file and --force", err)
+	}
+	if got := readProjectFile(t, projectDir, "lib/models/user.dart"); got != "// Edited by hand\n" {
+		t.Errorf("refused undo changed the edited file to %q", got)
+	}
+
+	if err := Undo(true); err != nil {
+		t.Fatalf("forced undo failed: %v", err)
+	}
+	if projectFileExists(projectDir, "lib/models/user.dart") {
+		t.Errorf("forced undo left the edited file")
+	}
+}
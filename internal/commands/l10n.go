@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// arbKeyPattern matches keys usable as AppLocalizations getters
+var arbKeyPattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+
+// l10nEnabled reports whether generated screens use AppLocalizations
+func l10nEnabled(cfg *config.Config) bool {
+	return cfg.L10n != nil && cfg.L10n.Enabled != nil && *cfg.L10n.Enabled
+}
+
+// arbDirPath returns the absolute path of the configured ARB directory
+func arbDirPath(cfg *config.Config) string {
+	dir := "lib/l10n"
+	if cfg.L10n != nil && cfg.L10n.ArbDir != nil && *cfg.L10n.ArbDir != "" {
+		dir = *cfg.L10n.ArbDir
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(*cfg.ProjectDir, dir)
+}
+
+// addL10nEntries adds entries to every ARB file and regenerates the localizations
+func addL10nEntries(cfg *config.Config, entries []utils.ArbEntry) error {
+	if err := utils.AddArbEntries(arbDirPath(cfg), entries); err != nil {
+		return fmt.Errorf("failed to update ARB files: %w", err)
+	}
+	if batch != nil {
+		batch.genL10n = true
+		return nil
+	}
+
+	return runGenL10n(*cfg.ProjectDir)
+}
+
+// runGenL10n regenerates the AppLocalizations classes
+func runGenL10n(projectDir string) error {
+	if err := utils.RunCommand(projectDir, "flutter", "gen-l10n"); err != nil {
+		return fmt.Errorf("failed to run flutter gen-l10n: %w", err)
+	}
+	return nil
+}
+
+// AddL10nString adds a localized string to every ARB file
+func AddL10nString(key, value string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !arbKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid key %q, expected a lowerCamelCase identifier", key)
+	}
+
+	return Transaction(func() error {
+		return addL10nEntries(cfg, []utils.ArbEntry{{Key: key, Value: value}})
+	})
+}
+
+// CheckL10n reports keys that are missing from any ARB file
+func CheckL10n() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	missing, err := utils.MissingArbKeys(arbDirPath(cfg))
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		fmt.Println("All ARB files define the same keys")
+		return nil
+	}
+
+	files := make([]string, 0, len(missing))
+	for file := range missing {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	count := 0
+	for _, file := range files {
+		fmt.Printf("%s is missing %d key(s):\n", filepath.Base(file), len(missing[file]))
+		for _, key := range missing[file] {
+			fmt.Printf("  - %s\n", key)
+		}
+		count += len(missing[file])
+	}
+	return fmt.Errorf("%d missing translation(s) found", count)
+}
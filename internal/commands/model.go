@@ -2,19 +2,31 @@ package commands
 
 import (
 	"bufio"
-	"flart/internal/config"
+	"errors"
 	"flart/internal/templates"
 	"flart/internal/utils"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 )
 
-func CreateModel(modelName string) error {
+// ErrCancelled is returned when the user declines to overwrite existing files
+var ErrCancelled = errors.New("operation cancelled by user")
+
+// typeNamePattern matches the class names inside a field type such as List<Order>?
+var typeNamePattern = regexp.MustCompile(`[A-Z][A-Za-z0-9_]*`)
+
+// ModelOptions holds the options accepted by make:model
+type ModelOptions struct {
+	Fields []utils.Field
+}
+
+func CreateModel(modelName string, opts ModelOptions) error {
 	// Load config
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -37,38 +49,40 @@ func CreateModel(modelName string) error {
 	modelFile := filepath.Join(modelDir, snakeCase+".dart")
 	testFile := filepath.Join(testDir, snakeCase+"_test.dart")
 
-	// Check existing files with user confirmation
-	existingFiles := []string{}
-	if utils.FileExists(modelFile) {
-		existingFiles = append(existingFiles, modelFile)
-	}
-	if utils.FileExists(testFile) {
-		existingFiles = append(existingFiles, testFile)
-	}
-
-	if len(existingFiles) > 0 {
-		fmt.Println("Warning: The following files already exist:")
-		for _, file := range existingFiles {
-			fmt.Printf("- %s\n", file)
-		}
-
-		fmt.Print("Do you want to overwrite these files? (y/N): ")
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
+	// Import field types that are other models of the project. The test builds the models and
+	// enums of the fields from their declarations in lib/models.
+	modelOpts := templates.ModelOptions{UseFreezed: useFreezed, Fields: opts.Fields}
+	if len(opts.Fields) > 0 {
+		packageName, err := utils.GetFlutterPackageName(projectDir)
 		if err != nil {
-			return fmt.Errorf("failed to read user input: %w", err)
+			return fmt.Errorf("failed to get package name: %w", err)
 		}
+		modelOpts.Imports = modelImports(projectDir, packageName, modelName, opts.Fields)
 
-		response = strings.ToLower(strings.TrimSpace(response))
-		if response != "y" && response != "yes" {
-			return fmt.Errorf("operation cancelled by user")
+		samples := newTestSamples(projectDir, packageName)
+		// A previous version of the model being generated does not describe its fields
+		samples.pending[utils.ToPascalCase(modelName)] = true
+		if err := samples.addFields(opts.Fields); err != nil {
+			return err
+		}
+		modelOpts.Samples = samples.samples
+		modelOpts.TestImports = modelOpts.Imports
+		for _, path := range samples.imports {
+			if !slices.Contains(modelOpts.TestImports, path) {
+				modelOpts.TestImports = append(modelOpts.TestImports[:len(modelOpts.TestImports):len(modelOpts.TestImports)], path)
+			}
 		}
 	}
 
+	// Check existing files with user confirmation
+	if err := confirmOverwrite(modelFile, testFile); err != nil {
+		return err
+	}
+
 	// Ensure directories exist
 	dirsToCreate := []string{modelDir, testDir}
 	for _, dir := range dirsToCreate {
-		if err := os.MkdirAll(dir, 0755); err != nil {
+		if err := utils.MkdirAll(dir); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
@@ -86,9 +100,17 @@ func CreateModel(modelName string) error {
 	}
 
 	// Prepare files to create
+	model, err := templates.GenerateModel(modelName, modelOpts)
+	if err != nil {
+		return err
+	}
+	test, err := templates.GenerateModelTest(modelName, modelOpts, projectDir)
+	if err != nil {
+		return err
+	}
 	files := map[string]string{
-		modelFile: templates.GenerateModel(modelName, useFreezed),
-		testFile:  templates.GenerateModelTest(modelName, useFreezed, projectDir),
+		modelFile: model,
+		testFile:  test,
 	}
 
 	// Write and format files
@@ -100,12 +122,8 @@ func CreateModel(modelName string) error {
 
 	// Run build_runner if freezed is enabled
 	if useFreezed {
-		cmd := exec.Command("dart", "run", "build_runner", "build", "--delete-conflicting-outputs")
-		cmd.Dir = projectDir
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to run build_runner: %w", err)
+		if err := runBuildRunner(projectDir); err != nil {
+			return err
 		}
 	}
 
@@ -117,17 +135,126 @@ func CreateModel(modelName string) error {
 	return nil
 }
 
-// Helper function to write and format file
+// Helper function to apply the overwrite policy to the given files, asking when needed
+func confirmOverwrite(paths ...string) error {
+	existingFiles := []string{}
+	for _, path := range paths {
+		if utils.FileExists(path) {
+			existingFiles = append(existingFiles, path)
+		}
+	}
+
+	if len(existingFiles) == 0 {
+		return nil
+	}
+
+	switch overwritePolicy {
+	case OverwriteAlways:
+		return nil
+	case OverwriteNever:
+		return fmt.Errorf("%w: %s", ErrKept, strings.Join(existingFiles, ", "))
+	case OverwriteNew, OverwriteMerge:
+		resolveConflicts(overwritePolicy, existingFiles)
+		return nil
+	}
+
+	// A dry run shows the changes to existing files instead of asking
+	if utils.DryRun() {
+		return nil
+	}
+	if !Interactive() {
+		return fmt.Errorf("%w: %s already exist, use --force to overwrite, --no-overwrite to keep them or --on-conflict to choose",
+			ErrNonInteractive, strings.Join(existingFiles, ", "))
+	}
+
+	fmt.Println("Warning: The following files already exist:")
+	for _, file := range existingFiles {
+		fmt.Printf("- %s\n", file)
+	}
+
+	fmt.Print("Do you want to overwrite these files? (y/N, new to write .new files, merge to merge your changes): ")
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read user input: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "y", "yes":
+		return nil
+	case "new":
+		resolveConflicts(OverwriteNew, existingFiles)
+		return nil
+	case "m", "merge":
+		resolveConflicts(OverwriteMerge, existingFiles)
+		return nil
+	default:
+		return ErrCancelled
+	}
+}
+
+// modelImports returns the imports of the project models and enums used in the field types,
+// leaving out the model named self
+func modelImports(projectDir, packageName, self string, fields []utils.Field) []string {
+	var imports []string
+	seen := map[string]bool{utils.ToSnakeCase(self): true}
+	for _, f := range fields {
+		for _, typeName := range typeNamePattern.FindAllString(f.Type, -1) {
+			snake := utils.ToSnakeCase(typeName)
+			if seen[snake] || !utils.FileExists(filepath.Join(projectDir, "lib", "models", snake+".dart")) {
+				continue
+			}
+			seen[snake] = true
+			imports = append(imports, fmt.Sprintf("package:%s/models/%s.dart", packageName, snake))
+		}
+	}
+	return imports
+}
+
+// Helper function to write and format file, or to write it as .new or merge it when chosen for an existing file
 func writeAndFormatFile(filePath, content, projectDir string) error {
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+	switch takeResolution(filePath) {
+	case OverwriteNew:
+		return writeNewFile(filePath, content, projectDir)
+	case OverwriteMerge:
+		return mergeFile(filePath, content, projectDir)
+	}
+
+	if err := utils.WriteFile(filePath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write file %s: %w", filePath, err)
 	}
+	rememberBase(filePath, nil)
+
+	return formatFile(filePath, projectDir)
+}
+
+// Helper function to format an existing file with dart format, leaving other languages as written
+func formatFile(filePath, projectDir string) error {
+	if filepath.Ext(filePath) != ".dart" {
+		return nil
+	}
+	if batch != nil {
+		batch.addFormatFile(filePath)
+		return nil
+	}
 
-	cmd := exec.Command("dart", "format", filePath)
-	cmd.Dir = projectDir
-	if err := cmd.Run(); err != nil {
+	if err := utils.RunQuietCommand(projectDir, "dart", "format", filePath); err != nil {
 		return fmt.Errorf("failed to format file %s: %w", filePath, err)
 	}
 
 	return nil
 }
+
+// Helper function to run build_runner once for the project
+func runBuildRunner(projectDir string) error {
+	if batch != nil {
+		batch.buildRunner = true
+		return nil
+	}
+
+	if err := utils.RunCommand(projectDir, "dart", "run", "build_runner", "build", "--delete-conflicting-outputs"); err != nil {
+		return fmt.Errorf("failed to run build_runner: %w", err)
+	}
+
+	return nil
+}
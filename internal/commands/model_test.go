@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"flart/internal/utils"
+	"strings"
+	"testing"
+)
+
+func TestModelTestBuildsModelFieldsFromTheirDeclaration(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "lib/models/role.dart", "enum Role { admin, member }\n")
+
+	userFields, err := utils.ParseFields("name:String,role:Role")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderFields, err := utils.ParseFields("user:User,note:String?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Transaction(func() error {
+		if err := CreateModel("User", ModelOptions{Fields: userFields}); err != nil {
+			return err
+		}
+		return CreateModel("Order", ModelOptions{Fields: orderFields})
+	})
+	if err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	content := readProjectFile(t, projectDir, "test/models/order_test.dart")
+	for _, want := range []string{
+		"import 'package:app/models/user.dart';",
+		"import 'package:app/models/role.dart';",
+		"user: User(name: 'name', role: Role.values.first)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("order test does not contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "user: null") {
+		t.Errorf("order test passes null for a required model:\n%s", content)
+	}
+}
+
+func TestModelRejectsFieldsWithoutTestValue(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	fields, err := utils.ParseFields("color:Color")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Transaction(func() error {
+		return CreateModel("Theme", ModelOptions{Fields: fields})
+	})
+	if err == nil || !strings.Contains(err.Error(), "no test value for color:Color") {
+		t.Fatalf("err = %v, want no test value for color:Color", err)
+	}
+	if projectFileExists(projectDir, "lib/models/theme.dart") {
+		t.Errorf("the rejected model was written")
+	}
+}
+
+func TestApplyBuildsModelSamplesFromManifestModels(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart.yaml", `enums:
+  - name: Status
+    values: [open, closed]
+models:
+  - name: Order
+    fields: [customer:Customer, status:Status]
+  - name: Customer
+    fields: "id:String,referrer:Customer?"
+`)
+
+	if err := Apply("flart.yaml"); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	content := readProjectFile(t, projectDir, "test/models/order_test.dart")
+	want := "customer: Customer(id: 'id')"
+	if !strings.Contains(content, want) {
+		t.Errorf("order test does not contain %q:\n%s", want, content)
+	}
+	if !strings.Contains(content, "status: Status.values.first") {
+		t.Errorf("order test does not use the manifest enum:\n%s", content)
+	}
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"errors"
+	"flart/internal/utils"
+	"fmt"
+	"strings"
+)
+
+// OverwritePolicy decides what happens when a generator would replace existing files
+type OverwritePolicy string
+
+// Overwrite policies selected by --force, --no-overwrite, --on-conflict or the default prompt
+const (
+	OverwriteAsk    OverwritePolicy = "ask"
+	OverwriteAlways OverwritePolicy = "overwrite"
+	OverwriteNever  OverwritePolicy = "skip"
+	// OverwriteNew writes the generated content next to each existing file, as <file>.new
+	OverwriteNew OverwritePolicy = "new"
+	// OverwriteMerge merges the user's edits with the generated content, using the content
+	// recorded when the file was last generated as the base
+	OverwriteMerge OverwritePolicy = "merge"
+)
+
+// OverwritePolicies lists the values accepted by --on-conflict
+var OverwritePolicies = []OverwritePolicy{OverwriteAsk, OverwriteAlways, OverwriteNever, OverwriteNew, OverwriteMerge}
+
+// ErrKept is returned when existing files are kept because of the overwrite policy
+var ErrKept = errors.New("existing files kept")
+
+// ErrNonInteractive is returned when a prompt is needed but cannot be answered
+var ErrNonInteractive = errors.New("cannot prompt in non-interactive mode")
+
+var (
+	overwritePolicy = OverwriteAsk
+	nonInteractive  bool
+	// resolutions holds the decision for each existing file that is written as .new or merged
+	resolutions = map[string]OverwritePolicy{}
+)
+
+// ParseOverwritePolicy returns the policy named by an --on-conflict value
+func ParseOverwritePolicy(value string) (OverwritePolicy, error) {
+	names := make([]string, len(OverwritePolicies))
+	for i, policy := range OverwritePolicies {
+		if string(policy) == value {
+			return policy, nil
+		}
+		names[i] = string(policy)
+	}
+	return "", fmt.Errorf("unknown conflict policy %q, expected one of %s", value, strings.Join(names, ", "))
+}
+
+// SetOverwritePolicy sets how every generator treats existing files
+func SetOverwritePolicy(policy OverwritePolicy) {
+	overwritePolicy = policy
+}
+
+// CurrentOverwritePolicy returns how generators treat existing files
+func CurrentOverwritePolicy() OverwritePolicy {
+	return overwritePolicy
+}
+
+// SetNonInteractive disables every prompt, even when stdin is a terminal
+func SetNonInteractive(disabled bool) {
+	nonInteractive = disabled
+}
+
+// Interactive reports whether prompts can be shown, which needs a terminal on stdin
+func Interactive() bool {
+	return !nonInteractive && utils.StdinIsTerminal()
+}
+
+// resolveConflicts records how each existing file is written once the policy or the user chose
+func resolveConflicts(policy OverwritePolicy, existingFiles []string) {
+	if policy != OverwriteNew && policy != OverwriteMerge {
+		return
+	}
+	for _, file := range existingFiles {
+		resolutions[file] = policy
+	}
+}
+
+// takeResolution returns and forgets the decision recorded for a file, OverwriteAlways when there is none
+func takeResolution(file string) OverwritePolicy {
+	policy, ok := resolutions[file]
+	if !ok {
+		return OverwriteAlways
+	}
+	delete(resolutions, file)
+	return policy
+}
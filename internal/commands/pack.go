@@ -0,0 +1,297 @@
+package commands
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// InstallPack copies the template pack in source, a directory or a .zip, .tar.gz or .tgz archive,
+// into the project. With use set, the pack is also selected as the templatePack of the config.
+func InstallPack(source string, use bool) (*templates.Pack, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	projectDir := *cfg.ProjectDir
+
+	dir := source
+	if isPackArchive(source) {
+		tmp, err := os.MkdirTemp("", "flart-pack-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(tmp)
+
+		if err := extractPackArchive(source, tmp); err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", source, err)
+		}
+		if dir, err = packRoot(tmp); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+	} else if info, err := os.Stat(source); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory or a .zip, .tar.gz or .tgz archive", source)
+	}
+
+	pack, err := templates.LoadPack(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Only the manifest and the declared templates are copied, not the rest of the source
+	files := pack.Files()
+
+	target := filepath.Join(projectDir, templates.PacksDir, pack.Name)
+	var installed []string
+	if utils.FileExists(target) {
+		if CurrentOverwritePolicy() != OverwriteAlways {
+			return nil, fmt.Errorf("pack %s is already installed, use --force to replace it", pack.Name)
+		}
+
+		// Files of the pack directory that the installed version does not declare are kept
+		previous, err := templates.LoadPack(target)
+		if err != nil {
+			fmt.Printf("Warning: %v, keeping the files it does not share with the new version\n", err)
+		} else {
+			installed = previous.Files()
+			if *cfg.TemplatePack == pack.Name {
+				warnDroppedTemplates(projectDir, previous, pack)
+			}
+		}
+	}
+
+	err = transaction(cfg, func() error {
+		// Files dropped by a new version of the pack are removed
+		for _, rel := range installed {
+			if file := filepath.Join(target, rel); !slices.Contains(files, rel) && utils.FileExists(file) {
+				if err := utils.RemoveFile(file); err != nil {
+					return fmt.Errorf("failed to remove %s: %w", rel, err)
+				}
+			}
+		}
+
+		for _, rel := range files {
+			content, err := os.ReadFile(filepath.Join(dir, rel))
+			if err != nil {
+				return err
+			}
+			file := filepath.Join(target, rel)
+			if err := utils.MkdirAll(filepath.Dir(file)); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", rel, err)
+			}
+			if err := utils.WriteFile(file, content); err != nil {
+				return fmt.Errorf("failed to write %s: %w", rel, err)
+			}
+		}
+
+		if use {
+			return config.SetTemplatePack(pack.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pack, nil
+}
+
+// ListPacks prints the built-in and installed template packs, marking the selected one
+func ListPacks() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	selected := *cfg.TemplatePack
+
+	installed, errs := templates.InstalledPacks(*cfg.ProjectDir)
+	packs := append([]*templates.Pack{templates.Builtin()}, installed...)
+
+	found := false
+	for _, pack := range packs {
+		marker := " "
+		if pack.Name == selected {
+			marker, found = "*", true
+		}
+		title := strings.TrimSpace(pack.Name + " " + pack.Version)
+		fmt.Printf("%s %s: %s\n", marker, title, pack.Description)
+		fmt.Printf("    artifacts: %s\n", strings.Join(pack.ArtifactNames(), ", "))
+		if deps := append(append([]string{}, pack.Dependencies...), pack.DevDependencies...); len(deps) > 0 {
+			fmt.Printf("    dependencies: %s\n", strings.Join(deps, ", "))
+		}
+		if len(pack.Parameters) > 0 {
+			fmt.Println("    parameters:")
+		}
+		for _, param := range pack.Parameters {
+			value := fmt.Sprintf("default %q", param.Default)
+			if param.Required {
+				value = "required"
+			}
+			fmt.Printf("      %s: %s (%s)\n", param.Name, param.Description, value)
+		}
+	}
+
+	for _, err := range errs {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	if !found {
+		fmt.Printf("Warning: templatePack %s is not installed\n", selected)
+	}
+	return nil
+}
+
+// warnDroppedTemplates warns about the templates of the selected pack that its new version no
+// longer provides. Unless overridden in .flart/templates, the embedded ones are used instead.
+func warnDroppedTemplates(projectDir string, previous, pack *templates.Pack) {
+	for _, name := range previous.Templates() {
+		if slices.Contains(pack.Templates(), name) {
+			continue
+		}
+		if utils.FileExists(filepath.Join(projectDir, templates.OverrideDir, filepath.FromSlash(name))) {
+			continue
+		}
+		fmt.Printf("Warning: pack %s no longer provides %s, the embedded template is used instead\n", pack.Name, name)
+	}
+}
+
+// addPackDependencies adds the dependencies of the selected template pack once one of its
+// artifacts was generated
+func addPackDependencies(projectDir string) error {
+	deps, devDeps := templates.PackDependencies()
+	for _, dep := range deps {
+		if err := utils.AddDependency(dep, projectDir); err != nil {
+			return fmt.Errorf("failed to add %s dependency: %w", dep, err)
+		}
+	}
+	for _, dep := range devDeps {
+		if err := utils.AddDevDependency(dep, projectDir); err != nil {
+			return fmt.Errorf("failed to add %s dependency: %w", dep, err)
+		}
+	}
+	return nil
+}
+
+// isPackArchive reports whether source names an archive rather than a pack directory
+func isPackArchive(source string) bool {
+	for _, ext := range []string{".zip", ".tar.gz", ".tgz"} {
+		if strings.HasSuffix(strings.ToLower(source), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// packRoot returns the directory holding the manifest of an extracted pack, either the
+// archive root or its single top level directory
+func packRoot(dir string) (string, error) {
+	if utils.FileExists(filepath.Join(dir, templates.PackManifestFile)) {
+		return dir, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		root := filepath.Join(dir, entries[0].Name())
+		if utils.FileExists(filepath.Join(root, templates.PackManifestFile)) {
+			return root, nil
+		}
+	}
+	return "", fmt.Errorf("no %s found at the root of the archive", templates.PackManifestFile)
+}
+
+// extractPackArchive extracts the regular files of a zip or gzipped tar archive into dir
+func extractPackArchive(archive, dir string) error {
+	if strings.HasSuffix(strings.ToLower(archive), ".zip") {
+		return extractZip(archive, dir)
+	}
+	return extractTarGz(archive, dir)
+}
+
+// extractZip extracts a zip archive into dir
+func extractZip(archive, dir string) error {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		src, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = extractFile(dir, f.Name, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractTarGz extracts a gzipped tar archive into dir
+func extractTarGz(archive, dir string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	r := tar.NewReader(gz)
+	for {
+		header, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := extractFile(dir, header.Name, r); err != nil {
+			return err
+		}
+	}
+}
+
+// extractFile writes one archive entry below dir, refusing paths that would leave it
+func extractFile(dir, name string, src io.Reader) error {
+	rel := filepath.FromSlash(name)
+	if !filepath.IsLocal(rel) {
+		return fmt.Errorf("archive entry %s is outside of the archive", name)
+	}
+
+	target := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	dst, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
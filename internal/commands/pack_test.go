@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePack writes a pack declaring the given screen templates into dir, with a README and
+// repository files that are not part of the pack
+func writePack(t *testing.T, dir, version string, screenFiles ...string) {
+	t.Helper()
+	manifest := "name: brand\nversion: " + version + "\nartifacts:\n  - name: screen\n    files:\n"
+	for _, file := range screenFiles {
+		manifest += "      - screen/" + file + "\n"
+		writeProjectFile(t, dir, "templates/screen/"+file, "// "+file+" "+version+"\n")
+	}
+	writeProjectFile(t, dir, "pack.yaml", manifest)
+	writeProjectFile(t, dir, "README.md", "# Brand\n")
+	writeProjectFile(t, dir, "templates/notes.txt", "notes\n")
+	writeProjectFile(t, dir, ".git/config", "[core]\n")
+}
+
+func TestInstallPackCopiesDeclaredFiles(t *testing.T) {
+	projectDir := newTestProject(t)
+	source := t.TempDir()
+	writePack(t, source, "1.0.0", "bloc.dart.tmpl")
+
+	if _, err := InstallPack(source, false); err != nil {
+		t.Fatalf("install failed: %v", err)
+	}
+
+	packDir := filepath.Join(projectDir, ".flart", "packs", "brand")
+	var installed []string
+	err := filepath.WalkDir(packDir, func(file string, entry os.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			rel, _ := filepath.Rel(packDir, file)
+			installed = append(installed, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "pack.yaml,templates/screen/bloc.dart.tmpl"; strings.Join(installed, ",") != want {
+		t.Errorf("installed files = %v, want %s", installed, want)
+	}
+}
+
+func TestReinstallPackRemovesOnlyDroppedTemplates(t *testing.T) {
+	projectDir := newTestProject(t)
+	SetOverwritePolicy(OverwriteAlways)
+	t.Cleanup(func() { SetOverwritePolicy(OverwriteAsk) })
+
+	source := t.TempDir()
+	writePack(t, source, "1.0.0", "bloc.dart.tmpl", "state.dart.tmpl")
+	if _, err := InstallPack(source, true); err != nil {
+		t.Fatalf("install failed: %v", err)
+	}
+	// A file of the user in the pack directory
+	writeProjectFile(t, projectDir, ".flart/packs/brand/CHANGES.md", "local notes\n")
+
+	update := t.TempDir()
+	writePack(t, update, "2.0.0", "bloc.dart.tmpl")
+	if _, err := InstallPack(update, false); err != nil {
+		t.Fatalf("reinstall failed: %v", err)
+	}
+
+	if content := readProjectFile(t, projectDir, ".flart/packs/brand/templates/screen/bloc.dart.tmpl"); content != "// bloc.dart.tmpl 2.0.0\n" {
+		t.Errorf("template was not updated: %q", content)
+	}
+	if projectFileExists(projectDir, ".flart/packs/brand/templates/screen/state.dart.tmpl") {
+		t.Errorf("dropped template was kept")
+	}
+	if !projectFileExists(projectDir, ".flart/packs/brand/CHANGES.md") {
+		t.Errorf("file not declared by the pack was removed")
+	}
+}
+
+func TestInstallPackRejectsArchiveEntriesOutsideIt(t *testing.T) {
+	projectDir := newTestProject(t)
+	source := t.TempDir()
+	writePack(t, source, "1.0.0", "bloc.dart.tmpl")
+
+	archive := filepath.Join(t.TempDir(), "brand.zip")
+	file, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(file)
+	for name, content := range map[string]string{
+		"pack.yaml":                       readProjectFile(t, source, "pack.yaml"),
+		"templates/screen/bloc.dart.tmpl": "// bloc\n",
+		"../escaped.txt":                  "outside\n",
+	} {
+		entry, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = InstallPack(archive, false)
+	if err == nil || !strings.Contains(err.Error(), "archive entry ../escaped.txt is outside of the archive") {
+		t.Fatalf("err = %v, want the ../ entry rejected", err)
+	}
+	if projectFileExists(projectDir, ".flart/packs/brand") {
+		t.Errorf("the pack was installed")
+	}
+	matches, err := filepath.Glob(filepath.Join(os.TempDir(), "escaped.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) > 0 {
+		t.Errorf("the ../ entry was extracted to %s", matches[0])
+	}
+}
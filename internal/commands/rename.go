@@ -0,0 +1,354 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/utils"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// screenClassSuffixes are the suffixes of the classes generated for a screen, after its Pascal case name
+var screenClassSuffixes = []string{
+	"Screen", "ScreenState", "View", "ViewState", "Bloc", "Cubit", "TabCubit", "Event", "InitialEvent",
+	"RefreshEvent", "State", "Status", "Fetched", "Refreshed", "Submitted", "PageFetcher", "Validators",
+}
+
+// screenCamelSuffixes are the suffixes of the top level variables generated for a screen, after its camel case name
+var screenCamelSuffixes = []string{"Route", "PageSize"}
+
+// buildRunnerSuffixes are appended by Freezed and json_serializable to the $ prefixed names they generate
+var buildRunnerSuffixes = []string{"FromJson", "ToJson", "CopyWith", "Impl"}
+
+// generatedPartPattern matches part directives of files written by build_runner
+var generatedPartPattern = regexp.MustCompile(`(?m)^part '[^']+\.(?:g|freezed)\.dart';`)
+
+// artifactRename describes how the files and identifiers of an artifact change
+type artifactRename struct {
+	// moves maps the current path of each file to its new path
+	moves map[string]string
+	// generated are build_runner outputs of the artifact, removed and rebuilt under the new name
+	generated []string
+	// identifier renames the class names and variables generated for the artifact
+	identifier func(name string) string
+}
+
+// RenameModel renames a model's file and test, its class and every import and reference to it
+func RenameModel(oldName, newName string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	projectDir := *cfg.ProjectDir
+
+	oldSnake, newSnake := utils.ToSnakeCase(oldName), utils.ToSnakeCase(newName)
+	if oldSnake == newSnake {
+		return fmt.Errorf("%s and %s are the same model", oldName, newName)
+	}
+
+	modelDir := filepath.Join(projectDir, "lib", "models")
+	testDir := filepath.Join(projectDir, "test", "models")
+	modelFile := filepath.Join(modelDir, oldSnake+".dart")
+	if !utils.FileExists(modelFile) {
+		return fmt.Errorf("model %s not found at %s", utils.ToPascalCase(oldName), modelFile)
+	}
+
+	rename := artifactRename{
+		moves: map[string]string{modelFile: filepath.Join(modelDir, newSnake+".dart")},
+	}
+	if testFile := filepath.Join(testDir, oldSnake+"_test.dart"); utils.FileExists(testFile) {
+		rename.moves[testFile] = filepath.Join(testDir, newSnake+"_test.dart")
+	}
+	for _, suffix := range []string{".g.dart", ".freezed.dart"} {
+		if file := filepath.Join(modelDir, oldSnake+suffix); utils.FileExists(file) {
+			rename.generated = append(rename.generated, file)
+		}
+	}
+
+	rename.identifier = derivedRenamer(utils.ToPascalCase(oldName), utils.ToPascalCase(newName), nil,
+		utils.ToCamelCase(oldName), utils.ToCamelCase(newName), nil)
+
+	return Transaction(func() error {
+		return renameArtifact(cfg, rename)
+	})
+}
+
+// RenameScreen renames a screen's directory, files and generated classes, and every import and reference to them
+func RenameScreen(oldName, newName string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	projectDir := *cfg.ProjectDir
+
+	oldSnake, newSnake := utils.ToSnakeCase(oldName), utils.ToSnakeCase(newName)
+	if oldSnake == newSnake {
+		return fmt.Errorf("%s and %s are the same screen", oldName, newName)
+	}
+
+	screensDir := filepath.Join(projectDir, "lib", "screens")
+	testsDir := filepath.Join(projectDir, "test", "screens")
+	screenDir := filepath.Join(screensDir, oldSnake)
+	if info, err := os.Stat(screenDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("screen %s not found at %s", utils.ToPascalCase(oldName), screenDir)
+	}
+	if utils.FileExists(filepath.Join(screensDir, newSnake)) {
+		return fmt.Errorf("screen %s already exists", utils.ToPascalCase(newName))
+	}
+
+	files, dirs, err := filesUnder(screenDir, filepath.Join(testsDir, oldSnake))
+	if err != nil {
+		return err
+	}
+
+	rename := artifactRename{moves: map[string]string{}}
+	for _, file := range files {
+		if strings.HasSuffix(file, ".g.dart") || strings.HasSuffix(file, ".freezed.dart") {
+			rename.generated = append(rename.generated, file)
+			continue
+		}
+
+		// Move lib/screens/<old>/... to lib/screens/<new>/..., renaming <old>_*.dart files
+		root := screensDir
+		if rel, err := filepath.Rel(testsDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			root = testsDir
+		}
+		rel, _ := filepath.Rel(filepath.Join(root, oldSnake), file)
+		base := filepath.Base(rel)
+		if rest, ok := strings.CutPrefix(base, oldSnake); ok && (strings.HasPrefix(rest, "_") || strings.HasPrefix(rest, ".")) {
+			base = newSnake + rest
+		}
+		rename.moves[file] = filepath.Join(root, newSnake, filepath.Dir(rel), base)
+	}
+
+	rename.identifier = derivedRenamer(utils.ToPascalCase(oldName), utils.ToPascalCase(newName), screenClassSuffixes,
+		utils.ToCamelCase(oldName), utils.ToCamelCase(newName), screenCamelSuffixes)
+
+	err = Transaction(func() error {
+		return renameArtifact(cfg, rename)
+	})
+	if err != nil {
+		return err
+	}
+
+	return removeEmptyDirs(dirs)
+}
+
+// renameArtifact moves the artifact's files and rewrites imports and identifiers across lib/ and test/
+func renameArtifact(cfg *config.Config, rename artifactRename) error {
+	projectDir := *cfg.ProjectDir
+	packageName, err := utils.GetFlutterPackageName(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get package name: %w", err)
+	}
+
+	for _, target := range rename.moves {
+		if utils.FileExists(target) {
+			return fmt.Errorf("%s already exists", target)
+		}
+	}
+
+	generated := map[string]bool{}
+	for _, file := range rename.generated {
+		generated[file] = true
+	}
+
+	// Parts follow the moved files to their new name, even when build_runner has not written them yet
+	targets := maps.Clone(rename.moves)
+	for file, target := range rename.moves {
+		for _, suffix := range []string{".g.dart", ".freezed.dart"} {
+			targets[strings.TrimSuffix(file, ".dart")+suffix] = strings.TrimSuffix(target, ".dart") + suffix
+		}
+	}
+
+	files, err := utils.ProjectDartFiles(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to list Dart files: %w", err)
+	}
+
+	rebuild := len(rename.generated) > 0 || diType(cfg) == config.DIInjectable
+	for _, file := range files {
+		if generated[file] {
+			continue
+		}
+
+		content, err := utils.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		text := string(content)
+
+		newFile, moved := rename.moves[file]
+		if !moved {
+			newFile = file
+		}
+
+		updated := utils.RewriteImports(text, func(uri string) string {
+			return renameImport(projectDir, packageName, file, newFile, uri, targets)
+		})
+		updated = renameIdentifiers(updated, rename.identifier)
+
+		if moved {
+			rebuild = rebuild || generatedPartPattern.MatchString(text)
+			if err := utils.RemoveFile(file); err != nil {
+				return fmt.Errorf("failed to remove file %s: %w", file, err)
+			}
+		} else if updated == text {
+			continue
+		}
+		if err := utils.WriteFile(newFile, []byte(updated)); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", newFile, err)
+		}
+	}
+
+	// build_runner writes the parts again under the new name
+	if err := removeFiles(rename.generated); err != nil {
+		return err
+	}
+	if rebuild {
+		return runBuildRunner(projectDir)
+	}
+	return nil
+}
+
+// renameImport returns the URI file should use for uri once file moved to newFile and the artifact to moves
+func renameImport(projectDir, packageName, file, newFile, uri string, moves map[string]string) string {
+	resolved := utils.ResolveImport(projectDir, packageName, file, uri)
+	if resolved == "" {
+		return uri
+	}
+
+	target, moved := moves[resolved]
+	if !moved {
+		if file == newFile {
+			return uri
+		}
+		target = resolved
+	}
+
+	if strings.HasPrefix(uri, "package:") {
+		rel, err := filepath.Rel(filepath.Join(projectDir, "lib"), target)
+		if err != nil {
+			return uri
+		}
+		return "package:" + packageName + "/" + filepath.ToSlash(rel)
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(newFile), target)
+	if err != nil {
+		return uri
+	}
+	return filepath.ToSlash(rel)
+}
+
+// derivedRenamer renames the identifiers generated for an artifact: its Pascal case name followed by
+// one of classSuffixes or by a form field event name such as EmailChanged, their private and
+// build_runner variants such as _LoginViewState or _$UserFromJson, and its camel case name followed
+// by one of camelSuffixes. Other identifiers starting with the name, such as userName, are kept.
+func derivedRenamer(oldPascal, newPascal string, classSuffixes []string, oldCamel, newCamel string, camelSuffixes []string) func(string) string {
+	isDerived := func(suffix string) bool {
+		if suffix == "" || slices.Contains(classSuffixes, suffix) {
+			return true
+		}
+		return len(classSuffixes) > 0 && isUpper(suffix[0]) && strings.HasSuffix(suffix, "Changed")
+	}
+
+	return func(name string) string {
+		if suffix, ok := strings.CutPrefix(name, oldCamel); ok && slices.Contains(camelSuffixes, suffix) {
+			return newCamel + suffix
+		}
+
+		prefix, rest := splitIdentifierPrefix(name)
+		suffix, ok := strings.CutPrefix(rest, oldPascal)
+		if !ok {
+			return name
+		}
+		derived := suffix
+		if strings.Contains(prefix, "$") {
+			for trimmed := true; trimmed; {
+				trimmed = false
+				for _, generated := range buildRunnerSuffixes {
+					if rest, ok := strings.CutSuffix(derived, generated); ok {
+						derived, trimmed = rest, true
+					}
+				}
+			}
+		}
+		if isDerived(derived) {
+			return prefix + newPascal + suffix
+		}
+		return name
+	}
+}
+
+// isUpper reports whether c is an ASCII upper case letter
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+// isIdentifierPart reports whether c can appear in a Dart identifier
+func isIdentifierPart(c byte) bool {
+	return c == '_' || c == '$' || isUpper(c) || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
+
+// renameIdentifiers replaces each identifier of a Dart source with rename(identifier). String
+// literals and comments are copied unchanged.
+func renameIdentifiers(source string, rename func(string) string) string {
+	var result strings.Builder
+	for i := 0; i < len(source); {
+		start := i
+		switch c := source[i]; {
+		case strings.HasPrefix(source[i:], "//"):
+			i = indexFrom(source, i, "\n")
+		case strings.HasPrefix(source[i:], "/*"):
+			i = indexFrom(source, i+2, "*/") + 2
+		case strings.HasPrefix(source[i:], "'''"), strings.HasPrefix(source[i:], `"""`):
+			i = indexFrom(source, i+3, source[i:i+3]) + 3
+		case c == '\'' || c == '"':
+			// Skip the string, honouring escaped quotes
+			for i++; i < len(source) && source[i] != c && source[i] != '\n'; i++ {
+				if source[i] == '\\' {
+					i++
+				}
+			}
+			i++
+		case c >= '0' && c <= '9':
+			// Numbers such as 1e5 are not identifiers
+			for i < len(source) && isIdentifierPart(source[i]) {
+				i++
+			}
+		case isIdentifierPart(c):
+			for i < len(source) && isIdentifierPart(source[i]) {
+				i++
+			}
+			result.WriteString(rename(source[start:i]))
+			continue
+		default:
+			i++
+		}
+		i = min(i, len(source))
+		result.WriteString(source[start:i])
+	}
+	return result.String()
+}
+
+// indexFrom returns the index of substr in s from start, or the length of s when it is missing
+func indexFrom(s string, start int, substr string) int {
+	if start > len(s) {
+		return len(s)
+	}
+	if i := strings.Index(s[start:], substr); i >= 0 {
+		return start + i
+	}
+	return len(s)
+}
+
+// splitIdentifierPrefix splits the leading _ and $ of an identifier from the rest
+func splitIdentifierPrefix(name string) (string, string) {
+	rest := strings.TrimLeft(name, "_$")
+	return name[:len(name)-len(rest)], rest
+}
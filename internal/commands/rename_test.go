@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenameModelOnlyRenamesItsClass(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "lib/models/user.dart", `import 'package:freezed_annotation/freezed_annotation.dart';
+
+part 'user.freezed.dart';
+part 'user.g.dart';
+
+// User is shown as 'User' in the profile
+@freezed
+class User with _$User {
+  const factory User({required String userName}) = _User;
+
+  factory User.fromJson(Map<String, dynamic> json) => _$UserFromJson(json);
+}
+
+enum UserRole { admin, member }
+`)
+	writeProjectFile(t, projectDir, "lib/models/user.g.dart", "part of 'user.dart';\n")
+	writeProjectFile(t, projectDir, "lib/profile.dart", `import 'package:app/models/user.dart';
+
+String describe(User user) => 'User: ${user.userName}';
+`)
+
+	if err := RenameModel("User", "Account"); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+
+	if projectFileExists(projectDir, "lib/models/user.dart") || projectFileExists(projectDir, "lib/models/user.g.dart") {
+		t.Errorf("the old model files are left")
+	}
+	files := map[string][]string{
+		"lib/models/account.dart": {
+			"part 'account.freezed.dart';\npart 'account.g.dart';",
+			"// User is shown as 'User' in the profile",
+			"class Account with _$Account {",
+			"const factory Account({required String userName}) = _Account;",
+			"factory Account.fromJson(Map<String, dynamic> json) => _$AccountFromJson(json);",
+			"enum UserRole { admin, member }",
+		},
+		"lib/profile.dart": {
+			"import 'package:app/models/account.dart';",
+			"String describe(Account user) => 'User: ${user.userName}';",
+		},
+	}
+	for file, wants := range files {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s does not contain %q:\n%s", file, want, content)
+			}
+		}
+	}
+}
+
+func TestRenameScreenRenamesGeneratedNames(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "lib/screens/home/home.dart", `import 'bloc/home_bloc.dart';
+
+final homeRoute = GoRoute(path: '/home');
+
+class HomeScreen {
+  final homeTitle = 'Home';
+  final bloc = HomeBloc()..add(HomeEmailChanged('a'));
+}
+`)
+	writeProjectFile(t, projectDir, "lib/screens/home/bloc/home_bloc.dart", "class HomeBloc {}\n\nclass HomeEmailChanged {}\n")
+	writeProjectFile(t, projectDir, "lib/router.dart", "import 'screens/home/home.dart';\n\nfinal routes = [homeRoute];\n\nclass HomeScreenTheme {}\n")
+
+	if err := RenameScreen("Home", "Dashboard"); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+
+	files := map[string][]string{
+		"lib/screens/dashboard/dashboard.dart": {
+			"import 'bloc/dashboard_bloc.dart';",
+			"final dashboardRoute = GoRoute(path: '/home');",
+			"class DashboardScreen {",
+			"final homeTitle = 'Home';",
+			"DashboardBloc()..add(DashboardEmailChanged('a'))",
+		},
+		"lib/screens/dashboard/bloc/dashboard_bloc.dart": {
+			"class DashboardBloc {}",
+		},
+		"lib/router.dart": {
+			"import 'screens/dashboard/dashboard.dart';",
+			"final routes = [dashboardRoute];",
+			"class HomeScreenTheme {}",
+		},
+	}
+	for file, wants := range files {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s does not contain %q:\n%s", file, want, content)
+			}
+		}
+	}
+}
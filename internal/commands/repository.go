@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+)
+
+// CreateRepository generates an abstract repository with an implementation stub.
+// With a model, CRUD methods for that model are declared.
+func CreateRepository(name, model string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	projectDir := *cfg.ProjectDir
+	repoDir := filepath.Join(projectDir, "lib", "repositories")
+	repoFile := filepath.Join(repoDir, utils.ToSnakeCase(name)+"_repository.dart")
+
+	// The model must already exist so that the repository compiles
+	if model != "" {
+		modelFile := filepath.Join(projectDir, "lib", "models", utils.ToSnakeCase(model)+".dart")
+		if !utils.FileExists(modelFile) {
+			return fmt.Errorf("model %s not found at %s", utils.ToPascalCase(model), modelFile)
+		}
+	}
+
+	packageName, err := utils.GetFlutterPackageName(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get package name: %w", err)
+	}
+
+	// Check existing files with user confirmation
+	if err := confirmOverwrite(repoFile); err != nil {
+		return err
+	}
+
+	if err := utils.MkdirAll(repoDir); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", repoDir, err)
+	}
+
+	// Set up the service locator if DI is enabled
+	if _, err := setupInjection(cfg, packageName); err != nil {
+		return fmt.Errorf("failed to set up dependency injection: %w", err)
+	}
+	injectable := diType(cfg) == config.DIInjectable
+
+	content, err := templates.GenerateRepository(name, model, packageName, injectable)
+	if err != nil {
+		return err
+	}
+	if err := writeAndFormatFile(repoFile, content, projectDir); err != nil {
+		return err
+	}
+
+	// Register the implementation with the service locator
+	pascalName := utils.ToPascalCase(name)
+	if err := register(cfg, packageName, registration{
+		className: pascalName + "RepositoryImpl",
+		asType:    pascalName + "Repository",
+		file:      repoFile,
+		lifetime:  lifetimeLazySingleton,
+	}); err != nil {
+		return fmt.Errorf("failed to register dependencies: %w", err)
+	}
+
+	if injectable {
+		if err := runBuildRunner(projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Update barrel file
+	if err := utils.UpdateBarrelFile(repoDir, name+"Repository", "repositories.dart"); err != nil {
+		return fmt.Errorf("failed to update barrel file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+)
+
+// routerType returns the configured router integration, defaulting to none
+func routerType(cfg *config.Config) string {
+	if cfg.Router == nil || cfg.Router.Type == nil {
+		return config.RouterNone
+	}
+	return *cfg.Router.Type
+}
+
+// routerFilePath returns the absolute path of the configured router file
+func routerFilePath(cfg *config.Config) string {
+	file := "lib/router.dart"
+	if cfg.Router != nil && cfg.Router.File != nil && *cfg.Router.File != "" {
+		file = *cfg.Router.File
+	}
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(*cfg.ProjectDir, file)
+}
+
+// setupRouter adds go_router and creates the router file if needed
+func setupRouter(cfg *config.Config) error {
+	if routerType(cfg) != config.RouterGoRouter {
+		return nil
+	}
+
+	if err := utils.AddDependency("go_router", *cfg.ProjectDir); err != nil {
+		return fmt.Errorf("failed to add go_router dependency: %w", err)
+	}
+
+	routerFile := routerFilePath(cfg)
+	if utils.FileExists(routerFile) {
+		return nil
+	}
+	if err := utils.MkdirAll(filepath.Dir(routerFile)); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(routerFile), err)
+	}
+	content, err := templates.GenerateRouter()
+	if err != nil {
+		return err
+	}
+	return writeAndFormatFile(routerFile, content, *cfg.ProjectDir)
+}
+
+// registerRoute adds a route defined in file to the router file
+func registerRoute(cfg *config.Config, packageName, file, route string) error {
+	if routerType(cfg) != config.RouterGoRouter {
+		return nil
+	}
+
+	importLine, err := utils.PackageImport(*cfg.ProjectDir, packageName, file)
+	if err != nil {
+		return err
+	}
+
+	routerFile := routerFilePath(cfg)
+	if err := utils.InsertAtMarker(routerFile, utils.RoutesMarker, importLine, route+","); err != nil {
+		return fmt.Errorf("failed to register route %s: %w", route, err)
+	}
+
+	return formatFile(routerFile, *cfg.ProjectDir)
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"slices"
+)
+
+// testSamples collects the test values of the models and enums of a project, along with the
+// imports generated tests need to construct them
+type testSamples struct {
+	projectDir  string
+	packageName string
+	// samples maps model and enum names to Dart expressions, e.g. User(id: 'id', role: Role.values.first)
+	samples map[string]string
+	// imports are the sorted imports of the resolved models and enums
+	imports []string
+	// pending holds the models being resolved, so that a model referencing itself is not followed
+	pending map[string]bool
+}
+
+// newTestSamples returns an empty set of test values for a project
+func newTestSamples(projectDir, packageName string) *testSamples {
+	return &testSamples{
+		projectDir:  projectDir,
+		packageName: packageName,
+		samples:     map[string]string{},
+		pending:     map[string]bool{},
+	}
+}
+
+// addFields resolves the project models and enums typed by the non-nullable fields. Fields
+// still lacking a test value are rejected, as the generated test would not compile.
+func (s *testSamples) addFields(fields []utils.Field) error {
+	for _, f := range fields {
+		if f.IsNullable() {
+			continue
+		}
+		if _, err := s.addModel(f.Type); err != nil {
+			return err
+		}
+		if _, ok := s.samples[f.Type]; !ok && f.SampleValue() == "null" {
+			return fmt.Errorf("no test value for %s:%s, use a model or enum of lib/models or make it nullable", f.Name, f.Type)
+		}
+	}
+	return nil
+}
+
+// addModel resolves the test value of a model or enum in lib/models, reporting whether one was found
+func (s *testSamples) addModel(name string) (bool, error) {
+	if _, ok := s.samples[name]; ok {
+		return true, nil
+	}
+	if s.pending[name] || typeNamePattern.FindString(name) != name {
+		return false, nil
+	}
+
+	model, err := utils.ReadProjectModel(s.projectDir, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to read model %s: %w", name, err)
+	}
+	if model == nil {
+		return false, nil
+	}
+
+	if model.Enum {
+		s.samples[name] = name + ".values.first"
+	} else {
+		s.pending[name] = true
+		for _, f := range model.Fields {
+			if !f.IsNullable() {
+				if _, err := s.addModel(f.Type); err != nil {
+					return false, err
+				}
+			}
+		}
+		delete(s.pending, name)
+		s.samples[name] = templates.ModelSample(name, model.Fields, s.samples)
+	}
+	// Imports are kept sorted so that the generated tests do not depend on the resolution order
+	path := fmt.Sprintf("package:%s/models/%s.dart", s.packageName, utils.ToSnakeCase(name))
+	i, _ := slices.BinarySearch(s.imports, path)
+	s.imports = slices.Insert(s.imports, i, path)
+	return true, nil
+}
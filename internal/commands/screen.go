@@ -5,17 +5,48 @@ import (
 	"flart/internal/templates"
 	"flart/internal/utils"
 	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 )
 
-func CreateScreen(screenName string) error {
-	cfg, err := config.Load()
+// ScreenOptions holds the options accepted by make:screen
+type ScreenOptions struct {
+	Form []utils.Field
+	List string
+	Tabs []string
+	// TopTabs uses a TabBar instead of a BottomNavigationBar for tabbed screens
+	TopTabs bool
+}
+
+func CreateScreen(screenName string, opts ScreenOptions) error {
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if err := templates.CheckFormFields(opts.Form); err != nil {
+		return err
+	}
+	if len(opts.Form) > 0 && opts.List != "" {
+		return fmt.Errorf("a screen cannot be both a form and a list")
+	}
+
+	// Tabbed screens are a shell around other screens
+	if len(opts.Tabs) > 0 {
+		if len(opts.Form) > 0 || opts.List != "" {
+			return fmt.Errorf("a tabbed screen cannot also be a form or a list")
+		}
+		return createTabsScreen(cfg, screenName, opts)
+	}
+
+	// List screens page through an existing model
+	if opts.List != "" {
+		modelFile := filepath.Join(*cfg.ProjectDir, "lib", "models", utils.ToSnakeCase(opts.List)+".dart")
+		if !utils.FileExists(modelFile) {
+			return fmt.Errorf("model %s not found at %s", utils.ToPascalCase(opts.List), modelFile)
+		}
+	}
+	hasTests := len(opts.Form) > 0 || opts.List != ""
+
 	// Add required dependencies
 	dependencies := []string{
 		"flutter_bloc",
@@ -36,6 +67,41 @@ func CreateScreen(screenName string) error {
 		}
 	}
 
+	// Set up the service locator if DI is enabled
+	var packageName, diImport string
+	if diType(cfg) != config.DINone {
+		packageName, err = utils.GetFlutterPackageName(*cfg.ProjectDir)
+		if err != nil {
+			return fmt.Errorf("failed to get package name: %w", err)
+		}
+		if diImport, err = setupInjection(cfg, packageName); err != nil {
+			return fmt.Errorf("failed to set up dependency injection: %w", err)
+		}
+	}
+	injectable := diType(cfg) == config.DIInjectable
+
+	// Paging blocs throttle fetch events with bloc_concurrency and stream_transform
+	if opts.List != "" && !*cfg.Screens.UseCubit {
+		for _, dep := range []string{"bloc_concurrency", "stream_transform"} {
+			if err := utils.AddDependency(dep, *cfg.ProjectDir); err != nil {
+				return fmt.Errorf("failed to add dependency %s: %w", dep, err)
+			}
+		}
+	}
+
+	// Form and list screens come with bloc tests
+	if hasTests {
+		if err := utils.AddDevDependency("bloc_test", *cfg.ProjectDir); err != nil {
+			return fmt.Errorf("failed to add bloc_test dependency: %w", err)
+		}
+		if packageName == "" {
+			packageName, err = utils.GetFlutterPackageName(*cfg.ProjectDir)
+			if err != nil {
+				return fmt.Errorf("failed to get package name: %w", err)
+			}
+		}
+	}
+
 	// Convert to snake case for file names
 	snakeCase := utils.ToSnakeCase(screenName)
 
@@ -48,40 +114,101 @@ func CreateScreen(screenName string) error {
 		stateDir = filepath.Join(screenDir, "bloc")
 	}
 
+	testDir := filepath.Join(*cfg.ProjectDir, "test/screens", snakeCase)
+
 	dirs := []string{screenDir, stateDir}
+	if hasTests {
+		dirs = append(dirs, testDir)
+	}
 	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
+		if err := utils.MkdirAll(dir); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
 
+	screenOpts := templates.ScreenOptions{
+		UseCubit:    *cfg.Screens.UseCubit,
+		UseFreezed:  *cfg.Screens.UseFreezed,
+		Injectable:  injectable,
+		DIImport:    diImport,
+		Form:        opts.Form,
+		ListItem:    opts.List,
+		PackageName: packageName,
+	}
+	if l10nEnabled(cfg) {
+		screenOpts.L10nImport = *cfg.L10n.Import
+	}
+
+	// List tests build items with a value for every required field of the model
+	if opts.List != "" {
+		samples := newTestSamples(*cfg.ProjectDir, packageName)
+		item := utils.ToPascalCase(opts.List)
+		if _, err := samples.addModel(item); err != nil {
+			return err
+		}
+		screenOpts.ItemSample, screenOpts.ItemImports = samples.samples[item], samples.imports
+	}
+
 	// Create files with templates
-	var files map[string]string
+	var generators map[string]func(string, templates.ScreenOptions) (string, error)
+	var reg registration
 	if *cfg.Screens.UseCubit {
-		files = map[string]string{
-			filepath.Join(screenDir, snakeCase+".dart"):      templates.GenerateScreen(screenName, true),
-			filepath.Join(stateDir, snakeCase+"_cubit.dart"): templates.GenerateCubit(screenName),
-			filepath.Join(stateDir, snakeCase+"_state.dart"): templates.GenerateState(screenName, true, *cfg.Screens.UseFreezed),
+		generators = map[string]func(string, templates.ScreenOptions) (string, error){
+			filepath.Join(screenDir, snakeCase+".dart"):      templates.GenerateScreen,
+			filepath.Join(stateDir, snakeCase+"_cubit.dart"): templates.GenerateCubit,
+			filepath.Join(stateDir, snakeCase+"_state.dart"): templates.GenerateState,
+		}
+		reg = registration{
+			className: utils.ToPascalCase(screenName) + "Cubit",
+			file:      filepath.Join(stateDir, snakeCase+"_cubit.dart"),
+			lifetime:  lifetimeFactory,
 		}
 	} else {
-		files = map[string]string{
-			filepath.Join(screenDir, snakeCase+".dart"):      templates.GenerateScreen(screenName, false),
-			filepath.Join(stateDir, snakeCase+"_bloc.dart"):  templates.GenerateBloc(screenName),
-			filepath.Join(stateDir, snakeCase+"_event.dart"): templates.GenerateEvent(screenName),
-			filepath.Join(stateDir, snakeCase+"_state.dart"): templates.GenerateState(screenName, false, *cfg.Screens.UseFreezed),
+		generators = map[string]func(string, templates.ScreenOptions) (string, error){
+			filepath.Join(screenDir, snakeCase+".dart"):      templates.GenerateScreen,
+			filepath.Join(stateDir, snakeCase+"_bloc.dart"):  templates.GenerateBloc,
+			filepath.Join(stateDir, snakeCase+"_event.dart"): templates.GenerateEvent,
+			filepath.Join(stateDir, snakeCase+"_state.dart"): templates.GenerateState,
+		}
+		reg = registration{
+			className: utils.ToPascalCase(screenName) + "Bloc",
+			file:      filepath.Join(stateDir, snakeCase+"_bloc.dart"),
+			lifetime:  lifetimeFactory,
 		}
 	}
 
-	for filePath, content := range files {
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
-			return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	// Add bloc or cubit tests for form and list screens
+	testFile := filepath.Join(testDir, snakeCase+"_bloc_test.dart")
+	if *cfg.Screens.UseCubit {
+		testFile = filepath.Join(testDir, snakeCase+"_cubit_test.dart")
+	}
+	if len(opts.Form) > 0 {
+		generators[testFile] = templates.GenerateFormTest
+	} else if opts.List != "" {
+		generators[testFile] = templates.GenerateListTest
+	}
+
+	files := make(map[string]string, len(generators))
+	for filePath, generate := range generators {
+		content, err := generate(screenName, screenOpts)
+		if err != nil {
+			return err
 		}
+		files[filePath] = content
+	}
 
-		// Format the created file using dart format
-		cmd := exec.Command("dart", "format", filePath)
-		cmd.Dir = *cfg.ProjectDir
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to format file %s: %w", filePath, err)
+	// Check existing files with user confirmation
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	if err := confirmOverwrite(paths...); err != nil {
+		return err
+	}
+
+	for filePath, content := range files {
+		if err := writeAndFormatFile(filePath, content, *cfg.ProjectDir); err != nil {
+			return err
 		}
 	}
 
@@ -91,14 +218,22 @@ func CreateScreen(screenName string) error {
 		return fmt.Errorf("failed to update barrel file: %w", err)
 	}
 
-	// Run build_runner if freezed is enabled
-	if *cfg.Screens.UseFreezed {
-		cmd := exec.Command("dart", "run", "build_runner", "build", "--delete-conflicting-outputs")
-		cmd.Dir = *cfg.ProjectDir
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to run build_runner: %w", err)
+	// Add the screen strings to every ARB file
+	if l10nEnabled(cfg) {
+		if err := addL10nEntries(cfg, templates.ScreenL10nEntries(screenName, screenOpts)); err != nil {
+			return err
+		}
+	}
+
+	// Register the bloc or cubit with the service locator
+	if err := register(cfg, packageName, reg); err != nil {
+		return fmt.Errorf("failed to register dependencies: %w", err)
+	}
+
+	// Run build_runner if freezed or injectable is enabled
+	if *cfg.Screens.UseFreezed || injectable {
+		if err := runBuildRunner(*cfg.ProjectDir); err != nil {
+			return err
 		}
 	}
 
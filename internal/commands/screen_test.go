@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"flart/internal/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestListTestBuildsItemsWithRequiredFields(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	userFields, err := utils.ParseFields("id:String,role:Role")
+	if err != nil {
+		t.Fatal(err)
+	}
+	postFields, err := utils.ParseFields("id:String,author:User,title:String?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Transaction(func() error {
+		if err := CreateEnum("Role", []string{"admin"}); err != nil {
+			return err
+		}
+		if err := CreateModel("User", ModelOptions{Fields: userFields}); err != nil {
+			return err
+		}
+		if err := CreateModel("Post", ModelOptions{Fields: postFields}); err != nil {
+			return err
+		}
+		return CreateScreen("Feed", ScreenOptions{List: "Post"})
+	})
+	if err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "test", "screens", "feed", "feed_bloc_test.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"import 'package:app/models/post.dart';",
+		"import 'package:app/models/role.dart';",
+		"import 'package:app/models/user.dart';",
+		"final lastPage = [Post(id: 'id', author: User(id: 'id', role: Role.values.first))];",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("list test does not contain %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestLocalizedScreenStrings(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	config := `{"l10n": {"enabled": true}}`
+	if err := os.WriteFile(filepath.Join(projectDir, "flart_config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	arb := filepath.Join(projectDir, "lib", "l10n", "app_en.arb")
+	if err := os.MkdirAll(filepath.Dir(arb), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(arb, []byte("{\n  \"@@locale\": \"en\"\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := utils.ParseFields("email:String")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Transaction(func() error {
+		if err := CreateScreen("Login", ScreenOptions{Form: form}); err != nil {
+			return err
+		}
+		return CreateScreen("Home", ScreenOptions{Tabs: []string{"Feed", "Settings"}})
+	})
+	if err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(arb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"loginEmailLabel", "loginSubmit", "loginSubmissionFailed", "homeFeedTab", "homeSettingsTab"} {
+		if !strings.Contains(string(content), `"`+key+`"`) {
+			t.Errorf("ARB file does not contain %s:\n%s", key, content)
+		}
+	}
+
+	screens := map[string]string{
+		"lib/screens/login/login.dart": "AppLocalizations.of(context)!.loginSubmit",
+		"lib/screens/home/home.dart":   "AppLocalizations.of(context)!.homeFeedTab",
+	}
+	for file, want := range screens {
+		content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("%s does not contain %q:\n%s", file, want, content)
+		}
+	}
+}
+
+func TestGoRouterTabsFollowNavigationShell(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", `{"router": {"type": "go_router"}}`)
+	// The second run replaces the shell screen of the first
+	SetOverwritePolicy(OverwriteAlways)
+	t.Cleanup(func() { SetOverwritePolicy(OverwriteAsk) })
+
+	for _, topTabs := range []bool{false, true} {
+		err := Transaction(func() error {
+			return CreateScreen("Home", ScreenOptions{Tabs: []string{"Feed", "Settings"}, TopTabs: topTabs})
+		})
+		if err != nil {
+			t.Fatalf("generation failed: %v", err)
+		}
+
+		content := readProjectFile(t, projectDir, "lib/screens/home/home.dart")
+		want := "currentIndex: navigationShell.currentIndex,"
+		if topTabs {
+			want = "_controller.animateTo(widget.navigationShell.currentIndex);"
+		}
+		if !strings.Contains(content, want) {
+			t.Errorf("top tabs %v: screen does not contain %q:\n%s", topTabs, want, content)
+		}
+		if strings.Contains(content, "TabCubit") || projectFileExists(projectDir, "lib/screens/home/cubit/home_tab_cubit.dart") {
+			t.Errorf("top tabs %v: the selected tab is kept in a cubit", topTabs)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+)
+
+// createTabsScreen creates a shell screen with one child screen per tab
+func createTabsScreen(cfg *config.Config, screenName string, opts ScreenOptions) error {
+	if len(opts.Tabs) < 2 {
+		return fmt.Errorf("a tabbed screen needs at least two tabs")
+	}
+
+	projectDir := *cfg.ProjectDir
+	screenBaseDir := filepath.Join(projectDir, "lib/screens")
+	goRouter := routerType(cfg) == config.RouterGoRouter
+
+	// Create each tab through the normal screen flow, reusing existing screens
+	for _, tab := range opts.Tabs {
+		tabFile := filepath.Join(screenBaseDir, utils.ToSnakeCase(tab), utils.ToSnakeCase(tab)+".dart")
+		if utils.FileExists(tabFile) {
+			fmt.Printf("Screen %s already exists, reusing it\n", tab)
+			continue
+		}
+		if err := CreateScreen(tab, ScreenOptions{}); err != nil {
+			return fmt.Errorf("failed to create tab screen %s: %w", tab, err)
+		}
+	}
+
+	// With go_router, the selected tab is the branch of the navigation shell rather than a cubit
+	if !goRouter {
+		if err := utils.AddDependency("flutter_bloc", projectDir); err != nil {
+			return fmt.Errorf("failed to add dependency flutter_bloc: %w", err)
+		}
+	}
+	if err := setupRouter(cfg); err != nil {
+		return fmt.Errorf("failed to set up router: %w", err)
+	}
+
+	snakeCase := utils.ToSnakeCase(screenName)
+	screenDir := filepath.Join(screenBaseDir, snakeCase)
+	cubitDir := filepath.Join(screenDir, "cubit")
+	screenFile := filepath.Join(screenDir, snakeCase+".dart")
+
+	tabsOpts := templates.TabsOptions{
+		Tabs:     opts.Tabs,
+		TopTabs:  opts.TopTabs,
+		GoRouter: goRouter,
+	}
+	if l10nEnabled(cfg) {
+		tabsOpts.L10nImport = *cfg.L10n.Import
+	}
+	screen, err := templates.GenerateTabsScreen(screenName, tabsOpts)
+	if err != nil {
+		return err
+	}
+	files := map[string]string{screenFile: screen}
+	if !goRouter {
+		cubit, err := templates.GenerateTabCubit(screenName)
+		if err != nil {
+			return err
+		}
+		files[filepath.Join(cubitDir, snakeCase+"_tab_cubit.dart")] = cubit
+	}
+
+	// Check existing files with user confirmation
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	if err := confirmOverwrite(paths...); err != nil {
+		return err
+	}
+
+	dir := screenDir
+	if !goRouter {
+		dir = cubitDir
+	}
+	if err := utils.MkdirAll(dir); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	for filePath, content := range files {
+		if err := writeAndFormatFile(filePath, content, projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Register the shell route with go_router
+	if goRouter {
+		packageName, err := utils.GetFlutterPackageName(projectDir)
+		if err != nil {
+			return fmt.Errorf("failed to get package name: %w", err)
+		}
+		if err := registerRoute(cfg, packageName, screenFile, utils.ToCamelCase(screenName)+"Route"); err != nil {
+			return err
+		}
+	}
+
+	// Update barrel file
+	if err := utils.UpdateScreenBarrelFile(screenBaseDir, screenName, "screens.dart"); err != nil {
+		return fmt.Errorf("failed to update barrel file: %w", err)
+	}
+
+	// Add the title and the tab labels to every ARB file
+	if l10nEnabled(cfg) {
+		if err := addL10nEntries(cfg, templates.TabsL10nEntries(screenName, tabsOpts)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
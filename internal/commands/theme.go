@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+)
+
+// themeFile is where the generated theme is written, relative to the project
+const themeFile = "lib/theme/app_theme.dart"
+
+// GenerateTheme writes ThemeData and typed token constants from a design token file.
+// Only the generated theme file is touched, so re-running after a token update is safe.
+func GenerateTheme(tokensFile string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	projectDir := *cfg.ProjectDir
+
+	tokens, err := utils.ParseDesignTokens(tokensFile)
+	if err != nil {
+		return err
+	}
+
+	err = Transaction(func() error {
+		outputFile := filepath.Join(projectDir, themeFile)
+		if err := utils.MkdirAll(filepath.Dir(outputFile)); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(outputFile), err)
+		}
+
+		content, err := templates.GenerateTheme(tokens, filepath.ToSlash(tokensFile))
+		if err != nil {
+			return err
+		}
+		return writeAndFormatFile(outputFile, content, projectDir)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !utils.DryRun() {
+		fmt.Printf("Generated theme in %s\n", themeFile)
+	}
+	return nil
+}
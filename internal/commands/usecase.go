@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"flart/internal/config"
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+	"regexp"
+)
+
+// UseCaseOptions holds the options accepted by make:usecase
+type UseCaseOptions struct {
+	Repo    string
+	Returns string
+	Params  []utils.Field
+}
+
+func CreateUseCase(useCaseName string, opts UseCaseOptions) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if opts.Repo == "" {
+		return fmt.Errorf("a repository is required, use --repo <Name>")
+	}
+
+	// Params follow the model Freezed setting
+	useFreezed := cfg.Models.UseFreezed != nil && *cfg.Models.UseFreezed
+
+	projectDir := *cfg.ProjectDir
+	useCaseDir := filepath.Join(projectDir, "lib", "usecases")
+	testDir := filepath.Join(projectDir, "test", "usecases")
+
+	// The repository must already exist so that the use case and its mock compile
+	repoFile := filepath.Join(projectDir, "lib", "repositories", utils.ToSnakeCase(opts.Repo)+"_repository.dart")
+	if !utils.FileExists(repoFile) {
+		return fmt.Errorf("repository %sRepository not found at %s", utils.ToPascalCase(opts.Repo), repoFile)
+	}
+
+	// Import the return type when it is one of the project's models
+	returnsModel := opts.Returns != "" &&
+		utils.FileExists(filepath.Join(projectDir, "lib", "models", utils.ToSnakeCase(opts.Returns)+".dart"))
+
+	packageName, err := utils.GetFlutterPackageName(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get package name: %w", err)
+	}
+
+	dirs := []string{useCaseDir, testDir}
+	for _, dir := range dirs {
+		if err := utils.MkdirAll(dir); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	// Add dependencies
+	if useFreezed {
+		if err := utils.AddFreezedDependencies(projectDir); err != nil {
+			return fmt.Errorf("failed to add freezed dependencies: %w", err)
+		}
+	} else if err := utils.AddDependency("equatable", projectDir); err != nil {
+		return fmt.Errorf("failed to add equatable dependency: %w", err)
+	}
+	if err := utils.AddDevDependency("mocktail", projectDir); err != nil {
+		return fmt.Errorf("failed to add mocktail dependency: %w", err)
+	}
+
+	// Set up the service locator if DI is enabled
+	if _, err := setupInjection(cfg, packageName); err != nil {
+		return fmt.Errorf("failed to set up dependency injection: %w", err)
+	}
+	injectable := diType(cfg) == config.DIInjectable
+
+	// The use case receives the repository from the service locator
+	if err := registerRepository(cfg, packageName, repoFile, opts.Repo); err != nil {
+		return err
+	}
+
+	// The test builds the params and the result with the project models and enums they use
+	samples := newTestSamples(projectDir, packageName)
+	resolve := opts.Params
+	if opts.Returns != "" && opts.Returns != "void" {
+		resolve = append(resolve[:len(resolve):len(resolve)], utils.Field{Name: "result", Type: opts.Returns})
+	}
+	if err := samples.addFields(resolve); err != nil {
+		return err
+	}
+
+	snakeCase := utils.ToSnakeCase(useCaseName)
+	useCase, err := templates.GenerateUseCase(
+		useCaseName, opts.Repo, opts.Returns, opts.Params, useFreezed, packageName, returnsModel, injectable)
+	if err != nil {
+		return err
+	}
+	test, err := templates.GenerateUseCaseTest(useCaseName, opts.Repo, opts.Returns, opts.Params, packageName, samples.samples, samples.imports)
+	if err != nil {
+		return err
+	}
+	files := map[string]string{
+		filepath.Join(useCaseDir, snakeCase+".dart"):   useCase,
+		filepath.Join(testDir, snakeCase+"_test.dart"): test,
+	}
+
+	// Check existing files with user confirmation
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	if err := confirmOverwrite(paths...); err != nil {
+		return err
+	}
+
+	for filePath, content := range files {
+		if err := writeAndFormatFile(filePath, content, projectDir); err != nil {
+			return err
+		}
+	}
+
+	// call() delegates to a repository method named after the use case
+	if err := addRepositoryMethod(projectDir, packageName, repoFile, useCaseName, opts, resolve); err != nil {
+		return fmt.Errorf("failed to add the repository method: %w", err)
+	}
+
+	// Register the use case with the service locator
+	if err := register(cfg, packageName, registration{
+		className: utils.ToPascalCase(useCaseName),
+		file:      filepath.Join(useCaseDir, snakeCase+".dart"),
+		lifetime:  lifetimeLazySingleton,
+		deps:      1,
+	}); err != nil {
+		return fmt.Errorf("failed to register dependencies: %w", err)
+	}
+
+	if useFreezed || injectable {
+		if err := runBuildRunner(projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Update barrel file
+	if err := utils.UpdateBarrelFile(useCaseDir, useCaseName, "usecases.dart"); err != nil {
+		return fmt.Errorf("failed to update barrel file: %w", err)
+	}
+
+	return nil
+}
+
+// addRepositoryMethod declares the method called by a use case in its repository and adds a stub
+// implementation, unless the repository already has a method of that name. Fields are the params
+// and the result of the use case, whose project models the repository imports.
+func addRepositoryMethod(projectDir, packageName, repoFile, useCaseName string, opts UseCaseOptions, fields []utils.Field) error {
+	content, err := utils.ReadFile(repoFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", repoFile, err)
+	}
+	method := regexp.MustCompile(`\b` + utils.ToCamelCase(useCaseName) + `\s*\(`)
+	if method.Match(content) {
+		return nil
+	}
+
+	var imports []string
+	for _, path := range modelImports(projectDir, packageName, "", fields) {
+		imports = append(imports, fmt.Sprintf("import '%s';", path))
+	}
+
+	repository := utils.ToPascalCase(opts.Repo) + "Repository"
+	declaration, implementation := templates.UseCaseRepositoryMethod(useCaseName, opts.Returns, opts.Params)
+	if err := utils.AddClassMember(repoFile, repository, declaration, imports); err != nil {
+		return err
+	}
+	if err := utils.AddClassMember(repoFile, repository+"Impl", implementation, nil); err != nil {
+		return err
+	}
+	return formatFile(repoFile, projectDir)
+}
+
+// registerRepository makes sure the repository a use case receives is available from the service
+// locator. With get_it, an implementation constructed without arguments, as generated by
+// make:repository, is registered when the injection file lacks it. Other repositories must be
+// registered by hand, as must implementations without the injectable annotation.
+func registerRepository(cfg *config.Config, packageName, repoFile, repoName string) error {
+	repository := utils.ToPascalCase(repoName) + "Repository"
+	injectionFile := injectionFilePath(cfg)
+
+	switch diType(cfg) {
+	case config.DIGetIt:
+		registered, err := utils.HasRegistration(injectionFile, repository)
+		if err != nil || registered {
+			return err
+		}
+
+		content, err := utils.ReadFile(repoFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", repoFile, err)
+		}
+		implementation := regexp.MustCompile(`\bclass\s+` + repository + `Impl\b`)
+		arguments := regexp.MustCompile(`\b` + repository + `Impl\s*\(\s*[^)\s]`)
+		if !implementation.Match(content) || arguments.Match(content) {
+			return fmt.Errorf("%s is not registered in %s, register it before generating use cases that depend on it",
+				repository, injectionFile)
+		}
+		return register(cfg, packageName, registration{
+			className: repository + "Impl",
+			asType:    repository,
+			file:      repoFile,
+			lifetime:  lifetimeLazySingleton,
+		})
+	case config.DIInjectable:
+		content, err := utils.ReadFile(repoFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", repoFile, err)
+		}
+		annotation := regexp.MustCompile(`@\w+\s*\(\s*as:\s*` + repository + `\s*[,)]`)
+		if !annotation.Match(content) {
+			return fmt.Errorf("%s is not registered with injectable, annotate its implementation with @LazySingleton(as: %s)",
+				repository, repository)
+		}
+	}
+	return nil
+}
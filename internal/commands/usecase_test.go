@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"flart/internal/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUseCaseAddsStubbedRepositoryMethod(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	repoFile := filepath.Join(projectDir, "lib", "repositories", "user_repository.dart")
+	if err := os.MkdirAll(filepath.Dir(repoFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	repo := "abstract class UserRepository {\n  // TODO: Declare repository methods\n}\n\nclass UserRepositoryImpl implements UserRepository {}\n"
+	if err := os.WriteFile(repoFile, []byte(repo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := utils.ParseFields("id:String")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Transaction(func() error {
+		if err := CreateModel("User", ModelOptions{}); err != nil {
+			return err
+		}
+		return CreateUseCase("GetUserProfile", UseCaseOptions{Repo: "User", Returns: "User", Params: params})
+	})
+	if err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	files := map[string][]string{
+		"lib/repositories/user_repository.dart": {
+			"import 'package:app/models/user.dart';\n\nabstract class UserRepository {",
+			"  Future<User> getUserProfile({required String id});\n}",
+			"  @override\n  Future<User> getUserProfile({required String id}) async {",
+		},
+		"lib/usecases/get_user_profile.dart": {
+			"return repository.getUserProfile(\n      id: params.id,\n    );",
+		},
+		"test/usecases/get_user_profile_test.dart": {
+			"final expected = User(id: 'id');",
+			"when(() => repository.getUserProfile(id: params.id))\n          .thenAnswer((_) async => expected);",
+			"expect(result, equals(expected));",
+			"verify(() => repository.getUserProfile(id: params.id)).called(1);",
+		},
+	}
+	for file, wants := range files {
+		content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s does not contain %q:\n%s", file, want, content)
+			}
+		}
+	}
+}
+
+func TestUseCaseRegistersItsRepository(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", `{"di": {"type": "get_it"}}`)
+	writeProjectFile(t, projectDir, "lib/repositories/user_repository.dart",
+		"abstract class UserRepository {}\n\nclass UserRepositoryImpl implements UserRepository {}\n")
+
+	err := Transaction(func() error {
+		return CreateUseCase("GetUsers", UseCaseOptions{Repo: "User"})
+	})
+	if err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	content := readProjectFile(t, projectDir, "lib/injection.dart")
+	repository := strings.Index(content, "sl.registerLazySingleton<UserRepository>(() => UserRepositoryImpl());")
+	useCase := strings.Index(content, "sl.registerLazySingleton<GetUsers>(() => GetUsers(sl()));")
+	if repository < 0 || useCase < repository {
+		t.Fatalf("injection file does not register the repository, then the use case:\n%s", content)
+	}
+}
+
+func TestUseCaseRejectsUnregisteredRepository(t *testing.T) {
+	projectDir := newTestProject(t)
+	writeProjectFile(t, projectDir, "flart_config.json", `{"di": {"type": "get_it"}}`)
+	// The implementation needs a client flart cannot provide
+	writeProjectFile(t, projectDir, "lib/repositories/user_repository.dart",
+		"abstract class UserRepository {}\n\nclass UserRepositoryImpl implements UserRepository {\n  UserRepositoryImpl(this.client);\n\n  final Object client;\n}\n")
+
+	err := Transaction(func() error {
+		return CreateUseCase("GetUsers", UseCaseOptions{Repo: "User"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "UserRepository is not registered") {
+		t.Fatalf("err = %v, want UserRepository is not registered", err)
+	}
+	if projectFileExists(projectDir, "lib/usecases/get_users.dart") {
+		t.Errorf("the rejected use case was written")
+	}
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"flart/internal/templates"
+	"flart/internal/utils"
+	"fmt"
+	"path/filepath"
+)
+
+// WidgetOptions holds the options accepted by make:widget
+type WidgetOptions struct {
+	Stateful bool
+	Golden   bool
+	Params   []utils.Field
+}
+
+func CreateWidget(widgetName string, opts WidgetOptions) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	projectDir := *cfg.ProjectDir
+	widgetDir := filepath.Join(projectDir, "lib", "widgets")
+	testDir := filepath.Join(projectDir, "test", "widgets")
+	goldenDir := filepath.Join(testDir, "goldens")
+
+	packageName, err := utils.GetFlutterPackageName(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get package name: %w", err)
+	}
+
+	snakeCase := utils.ToSnakeCase(widgetName)
+	// Params typed with project models and enums are imported and given test values
+	samples := newTestSamples(projectDir, packageName)
+	if err := samples.addFields(opts.Params); err != nil {
+		return err
+	}
+	widget, err := templates.GenerateWidget(widgetName, opts.Stateful, opts.Params, modelImports(projectDir, packageName, "", opts.Params))
+	if err != nil {
+		return err
+	}
+	test, err := templates.GenerateWidgetTest(widgetName, opts.Params, packageName, samples.samples, samples.imports)
+	if err != nil {
+		return err
+	}
+	files := map[string]string{
+		filepath.Join(widgetDir, snakeCase+".dart"):    widget,
+		filepath.Join(testDir, snakeCase+"_test.dart"): test,
+	}
+	dirs := []string{widgetDir, testDir}
+	if opts.Golden {
+		golden, err := templates.GenerateWidgetGoldenTest(widgetName, opts.Params, packageName, samples.samples, samples.imports)
+		if err != nil {
+			return err
+		}
+		files[filepath.Join(goldenDir, snakeCase+"_golden_test.dart")] = golden
+		dirs = append(dirs, goldenDir)
+	}
+
+	// Check existing files with user confirmation
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	if err := confirmOverwrite(paths...); err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		if err := utils.MkdirAll(dir); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	for filePath, content := range files {
+		if err := writeAndFormatFile(filePath, content, projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Update barrel file
+	if err := utils.UpdateBarrelFile(widgetDir, widgetName, "widgets.dart"); err != nil {
+		return fmt.Errorf("failed to update barrel file: %w", err)
+	}
+
+	return nil
+}
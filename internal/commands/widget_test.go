@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"flart/internal/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWidgetTestBuildsModelParams(t *testing.T) {
+	projectDir := newTestProject(t)
+
+	fields, err := utils.ParseFields("id:String,role:Role,manager:User?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := utils.ParseFields("user:User")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Transaction(func() error {
+		if err := CreateEnum("Role", []string{"admin", "member"}); err != nil {
+			return err
+		}
+		if err := CreateModel("User", ModelOptions{Fields: fields}); err != nil {
+			return err
+		}
+		return CreateWidget("Avatar", WidgetOptions{Params: params})
+	})
+	if err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "test", "widgets", "avatar_test.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"import 'package:app/models/role.dart';",
+		"import 'package:app/models/user.dart';",
+		"user: User(id: 'id', role: Role.values.first),",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("widget test does not contain %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestWidgetRejectsParamWithoutTestValue(t *testing.T) {
+	newTestProject(t)
+
+	params, err := utils.ParseFields("color:Color")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Transaction(func() error {
+		return CreateWidget("Swatch", WidgetOptions{Params: params})
+	})
+	if err == nil || !strings.Contains(err.Error(), "no test value for color:Color") {
+		t.Errorf("err = %v, want no test value for color:Color", err)
+	}
+}
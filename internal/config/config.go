@@ -2,10 +2,13 @@ package config
 
 import (
 	"encoding/json"
+	"flart/internal/utils"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -18,15 +21,140 @@ type ScreenConfig struct {
 	UseFreezed *bool `json:"useFreezed"`
 }
 
+// Supported service locator integrations
+const (
+	DINone       = "none"
+	DIGetIt      = "get_it"
+	DIInjectable = "injectable"
+)
+
+// Supported local storage backends for data sources
+const (
+	StorageSharedPreferences = "shared_preferences"
+	StorageHive              = "hive"
+)
+
+type DataConfig struct {
+	Dir          *string `json:"dir"`
+	LocalStorage *string `json:"localStorage"`
+}
+
+// Supported router integrations
+const (
+	RouterNone     = "none"
+	RouterGoRouter = "go_router"
+)
+
+type RouterConfig struct {
+	Type *string `json:"type"`
+	File *string `json:"file"`
+}
+
+type L10nConfig struct {
+	Enabled *bool   `json:"enabled"`
+	ArbDir  *string `json:"arbDir"`
+	Import  *string `json:"import"`
+}
+
+type DIConfig struct {
+	Type          *string `json:"type"`
+	InjectionFile *string `json:"injectionFile"`
+}
+
+// GeneratorConfig declares a custom artifact type, exposed as make:<name>
+type GeneratorConfig struct {
+	Description string          `json:"description"`
+	Files       []GeneratorFile `json:"files"`
+	// Barrel is the barrel file exporting the generated files below its directory, a path template
+	Barrel          string   `json:"barrel"`
+	Dependencies    []string `json:"dependencies"`
+	DevDependencies []string `json:"devDependencies"`
+}
+
+// GeneratorFile is a file written by a custom generator
+type GeneratorFile struct {
+	// Path is relative to the project and is itself a template, e.g. lib/dialogs/{{.Name.Snake}}_dialog.dart
+	Path string `json:"path"`
+	// Template is the template rendering the file, relative to .flart/templates
+	Template string `json:"template"`
+}
+
+// generatorNamePattern matches the names of custom generators
+var generatorNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
 type Config struct {
 	ProjectDir *string       `json:"projectDir"`
 	Models     *ModelConfig  `json:"models"`
 	Screens    *ScreenConfig `json:"screens"`
+	DI         *DIConfig     `json:"di"`
+	Data       *DataConfig   `json:"data"`
+	Router     *RouterConfig `json:"router"`
+	L10n       *L10nConfig   `json:"l10n"`
+	Flavors    []string      `json:"flavors,omitempty"`
+	// TemplatePack selects an installed template pack, builtin for the embedded templates
+	TemplatePack *string `json:"templatePack"`
+	// PackParams sets the parameters declared by the selected template pack
+	PackParams map[string]string `json:"packParams,omitempty"`
+	// Generators declares custom artifact types by name
+	Generators map[string]*GeneratorConfig `json:"generators,omitempty"`
 }
 
 // configFileName is consistent across save and load operations
 const configFileName = "flart_config.json"
 
+// Overrides holds per-invocation values that take precedence over the config file
+type Overrides struct {
+	ProjectDir        *string
+	ModelsUseFreezed  *bool
+	ScreensUseFreezed *bool
+	ScreensUseCubit   *bool
+}
+
+// overrides is applied by every Load, typically set from command line flags
+var overrides Overrides
+
+// Override merges the values set in o into the overrides applied by Load
+func Override(o Overrides) {
+	if o.ProjectDir != nil {
+		overrides.ProjectDir = o.ProjectDir
+	}
+	if o.ModelsUseFreezed != nil {
+		overrides.ModelsUseFreezed = o.ModelsUseFreezed
+	}
+	if o.ScreensUseFreezed != nil {
+		overrides.ScreensUseFreezed = o.ScreensUseFreezed
+	}
+	if o.ScreensUseCubit != nil {
+		overrides.ScreensUseCubit = o.ScreensUseCubit
+	}
+}
+
+// applyOverrides replaces config values with the ones set through Override
+func applyOverrides(cfg *Config) {
+	if overrides.ProjectDir != nil {
+		// Copy so that resolving the path does not change the override
+		projectDir := *overrides.ProjectDir
+		cfg.ProjectDir = &projectDir
+	}
+	if overrides.ModelsUseFreezed != nil {
+		if cfg.Models == nil {
+			cfg.Models = &ModelConfig{}
+		}
+		cfg.Models.UseFreezed = overrides.ModelsUseFreezed
+	}
+	if overrides.ScreensUseFreezed != nil || overrides.ScreensUseCubit != nil {
+		if cfg.Screens == nil {
+			cfg.Screens = &ScreenConfig{UseCubit: new(bool), UseFreezed: new(bool)}
+		}
+		if overrides.ScreensUseFreezed != nil {
+			cfg.Screens.UseFreezed = overrides.ScreensUseFreezed
+		}
+		if overrides.ScreensUseCubit != nil {
+			cfg.Screens.UseCubit = overrides.ScreensUseCubit
+		}
+	}
+}
+
 func Load() (*Config, error) {
 	// Create a default config with explicit default values
 	cfg := &Config{
@@ -38,6 +166,24 @@ func Load() (*Config, error) {
 			UseCubit:   new(bool),
 			UseFreezed: new(bool),
 		},
+		DI: &DIConfig{
+			Type:          new(string),
+			InjectionFile: new(string),
+		},
+		Data: &DataConfig{
+			Dir:          new(string),
+			LocalStorage: new(string),
+		},
+		Router: &RouterConfig{
+			Type: new(string),
+			File: new(string),
+		},
+		L10n: &L10nConfig{
+			Enabled: new(bool),
+			ArbDir:  new(string),
+			Import:  new(string),
+		},
+		TemplatePack: new(string),
 	}
 
 	// Set default values explicitly
@@ -45,6 +191,16 @@ func Load() (*Config, error) {
 	*cfg.Models.UseFreezed = false
 	*cfg.Screens.UseCubit = false
 	*cfg.Screens.UseFreezed = false
+	*cfg.DI.Type = DINone
+	*cfg.DI.InjectionFile = "lib/injection.dart"
+	*cfg.Data.Dir = "lib/data"
+	*cfg.Data.LocalStorage = StorageSharedPreferences
+	*cfg.Router.Type = RouterNone
+	*cfg.Router.File = "lib/router.dart"
+	*cfg.L10n.Enabled = false
+	*cfg.L10n.ArbDir = "lib/l10n"
+	*cfg.L10n.Import = "package:flutter_gen/gen_l10n/app_localizations.dart"
+	*cfg.TemplatePack = "builtin"
 
 	// Determine the config file path
 	currentDir, err := os.Getwd()
@@ -57,6 +213,10 @@ func Load() (*Config, error) {
 	// Check if config file exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		log.Printf("Config file not found at %s, using defaults", configPath)
+		applyOverrides(cfg)
+		if err := resolveProjectDir(cfg); err != nil {
+			return nil, err
+		}
 		return cfg, nil
 	}
 
@@ -71,13 +231,78 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
 	}
 
-	// Resolve project directory
+	// Validate DI integration
+	if cfg.DI != nil && cfg.DI.Type != nil {
+		switch *cfg.DI.Type {
+		case DINone, DIGetIt, DIInjectable:
+		default:
+			return nil, fmt.Errorf("unsupported di.type %q, expected %s, %s or %s", *cfg.DI.Type, DINone, DIGetIt, DIInjectable)
+		}
+	}
+
+	// Validate local storage backend
+	if cfg.Data != nil && cfg.Data.LocalStorage != nil {
+		switch *cfg.Data.LocalStorage {
+		case StorageSharedPreferences, StorageHive:
+		default:
+			return nil, fmt.Errorf("unsupported data.localStorage %q, expected %s or %s", *cfg.Data.LocalStorage, StorageSharedPreferences, StorageHive)
+		}
+	}
+
+	// Validate router integration
+	if cfg.Router != nil && cfg.Router.Type != nil {
+		switch *cfg.Router.Type {
+		case RouterNone, RouterGoRouter:
+		default:
+			return nil, fmt.Errorf("unsupported router.type %q, expected %s or %s", *cfg.Router.Type, RouterNone, RouterGoRouter)
+		}
+	}
+
+	if err := validateGenerators(cfg.Generators); err != nil {
+		return nil, err
+	}
+
+	applyOverrides(cfg)
+	if err := resolveProjectDir(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validateGenerators checks that every custom generator writes at least one file
+func validateGenerators(generators map[string]*GeneratorConfig) error {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		generator := generators[name]
+		if !generatorNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid generator name %q, expected lowercase letters, digits and _", name)
+		}
+		if generator == nil || len(generator.Files) == 0 {
+			return fmt.Errorf("generators.%s declares no files", name)
+		}
+		for i, file := range generator.Files {
+			if file.Path == "" || file.Template == "" {
+				return fmt.Errorf("generators.%s.files[%d] needs a path and a template", name, i)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveProjectDir expands ~ and makes the project directory absolute
+func resolveProjectDir(cfg *Config) error {
 	if cfg.ProjectDir != nil {
 		// Handle home directory expansion
 		if strings.HasPrefix(*cfg.ProjectDir, "~/") {
 			homeDir, err := os.UserHomeDir()
 			if err != nil {
-				return nil, fmt.Errorf("failed to get home directory: %w", err)
+				return fmt.Errorf("failed to get home directory: %w", err)
 			}
 			*cfg.ProjectDir = filepath.Join(homeDir, (*cfg.ProjectDir)[2:])
 		}
@@ -85,12 +310,62 @@ func Load() (*Config, error) {
 		// Convert to absolute path
 		absPath, err := filepath.Abs(*cfg.ProjectDir)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve project directory: %w", err)
+			return fmt.Errorf("failed to resolve project directory: %w", err)
 		}
 		*cfg.ProjectDir = absPath
 	}
 
-	return cfg, nil
+	return nil
+}
+
+// SetFlavors stores the flavor list in the config file, leaving the other settings untouched
+func SetFlavors(flavors []string) error {
+	return setValue("flavors", flavors)
+}
+
+// SetTemplatePack selects a template pack in the config file, leaving the other settings untouched
+func SetTemplatePack(name string) error {
+	return setValue("templatePack", name)
+}
+
+// setValue stores one top level key in the config file
+func setValue(key string, v any) error {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current working directory: %w", err)
+	}
+
+	configPath := filepath.Join(currentDir, configFileName)
+
+	// Edit the raw file so that unset options keep their defaults
+	raw := map[string]json.RawMessage{}
+	if data, err := utils.ReadFile(configPath); err == nil {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+	raw[key] = value
+
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := utils.WriteFile(configPath, data); err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", configPath, err)
+	}
+
+	if !utils.DryRun() {
+		log.Printf("Config saved to %s", configPath)
+	}
+	return nil
 }
 
 func Save(cfg *Config) error {
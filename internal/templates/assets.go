@@ -0,0 +1,77 @@
+package templates
+
+import (
+	"flart/internal/utils"
+	"fmt"
+)
+
+// assetClasses names the generated class for each asset kind
+var assetClasses = []struct {
+	kind      utils.AssetKind
+	className string
+}{
+	{utils.AssetImage, "AssetImages"},
+	{utils.AssetFont, "AssetFonts"},
+	{utils.AssetJSON, "AssetJson"},
+}
+
+// assetClass is a generated class of asset constants
+type assetClass struct {
+	Name      string
+	Constants []assetConstant
+}
+
+// assetConstant is a static constant holding an asset path or font family
+type assetConstant struct {
+	Name  string
+	Value string
+}
+
+// assetsData is the context of the assets template
+type assetsData struct {
+	Context
+	// Classes holds the non-empty classes, in images, fonts, JSON order
+	Classes []assetClass
+}
+
+// GenerateAssets creates typed constants for images, fonts and JSON files
+func GenerateAssets(assets []utils.Asset, fonts []utils.FontFamily) (string, error) {
+	data := assetsData{Context: newContext("", nil, "")}
+
+	for _, class := range assetClasses {
+		used := map[string]int{}
+		var constants []assetConstant
+
+		unique := func(name string) string {
+			used[name]++
+			if used[name] > 1 {
+				return fmt.Sprintf("%s%d", name, used[name])
+			}
+			return name
+		}
+
+		// Font families are what TextStyle.fontFamily expects
+		if class.kind == utils.AssetFont {
+			for _, font := range fonts {
+				name := unique(utils.ToCamelCase(font.Family))
+				constants = append(constants, assetConstant{Name: name, Value: font.Family})
+			}
+		}
+
+		for _, asset := range assets {
+			if asset.Kind != class.kind {
+				continue
+			}
+			name := unique(utils.AssetIdentifier(asset.Path))
+			constants = append(constants, assetConstant{Name: name, Value: asset.Path})
+		}
+
+		if len(constants) == 0 {
+			continue
+		}
+
+		data.Classes = append(data.Classes, assetClass{Name: class.className, Constants: constants})
+	}
+
+	return render("assets/assets.dart.tmpl", data)
+}
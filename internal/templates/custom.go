@@ -0,0 +1,48 @@
+package templates
+
+import (
+	"flart/internal/config"
+	"flart/internal/utils"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// customTemplates returns the templates used by the custom generators of cfg, which are
+// accepted under .flart/templates besides the embedded ones
+func customTemplates(cfg *config.Config) map[string]bool {
+	names := map[string]bool{}
+	if cfg == nil {
+		return names
+	}
+	for _, generator := range cfg.Generators {
+		for _, file := range generator.Files {
+			names[file.Template] = true
+		}
+	}
+	return names
+}
+
+// HasTemplate reports whether a template is loaded, either embedded or from the project
+func HasTemplate(name string) bool {
+	return templateSet().Lookup(name) != nil
+}
+
+// GenerateCustom renders a file of a custom generator
+func GenerateCustom(templateName, name string, fields []utils.Field) (string, error) {
+	return render(templateName, newContext(name, fields, ""))
+}
+
+// CustomPath renders a path pattern of a custom generator, e.g. lib/dialogs/{{.Name.Snake}}_dialog.dart
+func CustomPath(pattern, name string, fields []utils.Field) (string, error) {
+	t, err := template.New(pattern).Funcs(funcs).Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse path %s: %w", pattern, err)
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, newContext(name, fields, "")); err != nil {
+		return "", fmt.Errorf("failed to render path %s: %w", pattern, err)
+	}
+	return b.String(), nil
+}
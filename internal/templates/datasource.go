@@ -0,0 +1,59 @@
+package templates
+
+import (
+	"fmt"
+)
+
+// datasourceData is the context of the data source templates
+type datasourceData struct {
+	Context
+	UseHive bool
+	// ImportPrefix is the URI of the data sources directory imported by tests
+	ImportPrefix string
+	// InjectableImport and Annotation register the implementation with injectable when enabled
+	InjectableImport string
+	Annotation       string
+}
+
+// newDatasourceData returns the template context of a data source, registered as the given interface
+func newDatasourceData(name, iface string, injectable bool) datasourceData {
+	data := datasourceData{Context: newContext(name, nil, "")}
+	data.InjectableImport, data.Annotation = injectableAnnotation(injectable, fmt.Sprintf("@LazySingleton(as: %s%s)", data.Name.Pascal, iface))
+	return data
+}
+
+// GenerateDataExceptions creates the typed exceptions thrown by data sources
+func GenerateDataExceptions() (string, error) {
+	return render("datasource/exceptions.dart.tmpl", datasourceData{})
+}
+
+// GenerateRemoteDataSource creates a remote data source interface and its Dio implementation
+func GenerateRemoteDataSource(name string, injectable bool) (string, error) {
+	return render("datasource/remote_data_source.dart.tmpl", newDatasourceData(name, "RemoteDataSource", injectable))
+}
+
+// GenerateLocalDataSource creates a local data source interface backed by shared_preferences or Hive
+func GenerateLocalDataSource(name string, useHive, injectable bool) (string, error) {
+	data := newDatasourceData(name, "LocalDataSource", injectable)
+	data.UseHive = useHive
+	return render("datasource/local_data_source.dart.tmpl", data)
+}
+
+// GenerateRemoteDataSourceTest creates a unit test for the remote data source with a mocked Dio client
+func GenerateRemoteDataSourceTest(name, importPrefix string) (string, error) {
+	data := newDatasourceData(name, "RemoteDataSource", false)
+	data.ImportPrefix = importPrefix
+	return render("datasource/remote_data_source_test.dart.tmpl", data)
+}
+
+// GenerateLocalDataSourceTest creates a unit test for the local data source
+func GenerateLocalDataSourceTest(name, importPrefix string, useHive bool) (string, error) {
+	data := newDatasourceData(name, "LocalDataSource", false)
+	data.ImportPrefix, data.UseHive = importPrefix, useHive
+	return render("datasource/local_data_source_test.dart.tmpl", data)
+}
+
+// GenerateDataModule creates the injectable module providing the instances data sources depend on
+func GenerateDataModule() (string, error) {
+	return render("datasource/data_module.dart.tmpl", datasourceData{})
+}
@@ -0,0 +1,13 @@
+package templates
+
+// enumData is the context of the enum template
+type enumData struct {
+	Context
+	// Values are the enum values as given, converted to camelCase by the template
+	Values []string
+}
+
+// GenerateEnum creates a Dart enum with camelCase values
+func GenerateEnum(name string, values []string) (string, error) {
+	return render("enum/enum.dart.tmpl", enumData{Context: newContext(name, nil, ""), Values: values})
+}
@@ -0,0 +1,54 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// flavorData is the context of the flavor templates
+type flavorData struct {
+	Context
+	// Flavors are all the flavors of the project
+	Flavors []string
+	// Flavor is the flavor an entrypoint runs
+	Flavor string
+}
+
+// GenerateAppConfig creates the Flavor enum and the typed AppConfig read from dart defines
+func GenerateAppConfig(flavors []string) (string, error) {
+	return render("flavors/app_config.dart.tmpl", flavorData{Context: newContext("", nil, ""), Flavors: flavors})
+}
+
+// GenerateFlavorMain creates the main_<flavor>.dart entrypoint
+func GenerateFlavorMain(flavor, packageName string) (string, error) {
+	return render("flavors/main.dart.tmpl", flavorData{Context: newContext(flavor, nil, packageName), Flavor: flavor})
+}
+
+// GenerateFlavorEnv creates the JSON file passed to --dart-define-from-file
+func GenerateFlavorEnv(flavor, appName string) (string, error) {
+	host := flavor + ".api.example.com"
+	if flavor == "prod" {
+		host = "api.example.com"
+	} else {
+		appName += " " + strings.ToUpper(flavor[:1]) + flavor[1:]
+	}
+
+	// Keys are kept in a fixed order so the file is stable across runs
+	values := []struct{ key, value string }{
+		{"FLAVOR", flavor},
+		{"APP_NAME", appName},
+		{"API_BASE_URL", "https://" + host},
+	}
+
+	lines := make([]string, 0, len(values))
+	for _, v := range values {
+		encoded, err := json.Marshal(v.value)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode %s: %w", v.key, err)
+		}
+		lines = append(lines, fmt.Sprintf("  %q: %s", v.key, encoded))
+	}
+
+	return "{\n" + strings.Join(lines, ",\n") + "\n}\n", nil
+}
@@ -0,0 +1,195 @@
+package templates
+
+import (
+	"flart/internal/utils"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var validatorPattern = regexp.MustCompile(`^(required|email|min|max)(?:\((\d+)\))?$`)
+
+// CheckFormFields reports form fields with unsupported types or validators
+func CheckFormFields(fields []utils.Field) error {
+	for _, f := range fields {
+		if !isText(f) && !isNumeric(f) && !isBool(f) {
+			return fmt.Errorf("unsupported type %s on field %s, expected String, int, double, num or bool", f.Type, f.Name)
+		}
+		for _, v := range f.Validators {
+			match := validatorPattern.FindStringSubmatch(v)
+			if match == nil {
+				return fmt.Errorf("unsupported validator %q on field %s, expected required, email, min(n) or max(n)", v, f.Name)
+			}
+			if (match[1] == "min" || match[1] == "max") != (match[2] != "") {
+				return fmt.Errorf("invalid validator %q on field %s", v, f.Name)
+			}
+			if isBool(f) && match[1] != "required" {
+				return fmt.Errorf("unsupported validator %q on bool field %s, expected required", v, f.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// fieldLabel turns a field name into a human readable label, e.g. confirmPassword -> Confirm password
+func fieldLabel(f utils.Field) string {
+	label := strings.ReplaceAll(utils.ToSnakeCase(f.Name), "_", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// hasValidator reports whether the field declares the given validator and returns its argument
+func hasValidator(f utils.Field, name string) (bool, int) {
+	for _, v := range f.Validators {
+		match := validatorPattern.FindStringSubmatch(v)
+		if match != nil && match[1] == name {
+			n, _ := strconv.Atoi(match[2])
+			return true, n
+		}
+	}
+	return false, 0
+}
+
+// isRequired reports whether a form field must be filled in, either declared required or not nullable.
+// A bool field must be checked only when declared required.
+func isRequired(f utils.Field) bool {
+	required, _ := hasValidator(f, "required")
+	return required || (!f.IsNullable() && !isBool(f))
+}
+
+// isText reports whether the field holds text typed into a text field
+func isText(f utils.Field) bool {
+	return strings.TrimSuffix(f.Type, "?") == "String"
+}
+
+// isNumeric reports whether the field holds a number typed into a text field
+func isNumeric(f utils.Field) bool {
+	switch strings.TrimSuffix(f.Type, "?") {
+	case "int", "double", "num":
+		return true
+	}
+	return false
+}
+
+// isBool reports whether the field is edited with a checkbox
+func isBool(f utils.Field) bool {
+	return strings.TrimSuffix(f.Type, "?") == "bool"
+}
+
+// stateType returns the Dart type a form state holds the field as. Text is empty rather than null,
+// and numbers are null until the input parses.
+func stateType(f utils.Field) string {
+	switch {
+	case isText(f):
+		return "String"
+	case isNumeric(f):
+		return strings.TrimSuffix(f.Type, "?") + "?"
+	}
+	return f.Type
+}
+
+// stateDefault returns the initial value of the field in a form state, empty for null
+func stateDefault(f utils.Field) string {
+	switch stateType(f) {
+	case "String":
+		return "''"
+	case "bool":
+		return "false"
+	}
+	return ""
+}
+
+// validValue returns a Dart value of the field that passes every validator on the field
+func validValue(f utils.Field) string {
+	if isBool(f) {
+		return "true"
+	}
+	if isNumeric(f) {
+		value := 1
+		if ok, n := hasValidator(f, "min"); ok && value < n {
+			value = n
+		}
+		if ok, n := hasValidator(f, "max"); ok && value > n {
+			value = n
+		}
+		return strconv.Itoa(value)
+	}
+
+	value := "value"
+	if ok, _ := hasValidator(f, "email"); ok {
+		value = "user@example.com"
+	}
+	if ok, n := hasValidator(f, "min"); ok && len(value) < n {
+		value = strings.Repeat("a", n-len(value)) + value
+	}
+	if ok, n := hasValidator(f, "max"); ok && len(value) > n {
+		value = value[:n]
+	}
+	return "'" + value + "'"
+}
+
+// formValidator renders a static validator method for a form field. Text and numbers are validated
+// as typed, while bool fields are validated as checked.
+func formValidator(f utils.Field) string {
+	label := fieldLabel(f)
+	if isBool(f) {
+		var check string
+		if isRequired(f) {
+			check = fmt.Sprintf(`
+    if (value != true) {
+      return '%s is required';
+    }`, label)
+		}
+		return fmt.Sprintf(`static String? %s(bool? value) {%s
+    return null;
+  }`, f.Name, check)
+	}
+
+	var checks []string
+	if isRequired(f) {
+		checks = append(checks, fmt.Sprintf(`if (input.isEmpty) {
+      return '%s is required';
+    }`, label))
+	} else {
+		checks = append(checks, `if (input.isEmpty) {
+      return null;
+    }`)
+	}
+	if ok, _ := hasValidator(f, "email"); ok {
+		checks = append(checks, `if (!RegExp(r'^[^@\s]+@[^@\s]+\.[^@\s]+$').hasMatch(input)) {
+      return 'Enter a valid email';
+    }`)
+	}
+
+	// min and max bound the value of numbers and the length of text
+	subject, unit := "input.length", " characters"
+	if isNumeric(f) {
+		parser := strings.TrimSuffix(f.Type, "?")
+		checks = append(checks, fmt.Sprintf(`final number = %s.tryParse(input);
+    if (number == null) {
+      return '%s must be a number';
+    }`, parser, label))
+		subject, unit = "number", ""
+	}
+	if ok, n := hasValidator(f, "min"); ok {
+		checks = append(checks, fmt.Sprintf(`if (%s < %d) {
+      return '%s must be at least %d%s';
+    }`, subject, n, label, n, unit))
+	}
+	if ok, n := hasValidator(f, "max"); ok {
+		checks = append(checks, fmt.Sprintf(`if (%s > %d) {
+      return '%s must be at most %d%s';
+    }`, subject, n, label, n, unit))
+	}
+
+	return fmt.Sprintf(`static String? %s(String? value) {
+    final input = value ?? '';
+    %s
+    return null;
+  }`, f.Name, strings.Join(checks, "\n    "))
+}
+
+// GenerateFormTest creates bloc or cubit tests covering field validation and submission
+func GenerateFormTest(screenName string, opts ScreenOptions) (string, error) {
+	return render("form/test.dart.tmpl", newScreenData(screenName, opts))
+}
@@ -0,0 +1,107 @@
+package templates
+
+import (
+	"flart/internal/utils"
+	"strings"
+	"testing"
+)
+
+func parseField(t *testing.T, spec string) utils.Field {
+	t.Helper()
+	fields, err := utils.ParseFields(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fields[0]
+}
+
+func TestFormValidatorBoundsNumbersByValue(t *testing.T) {
+	validator := formValidator(parseField(t, "age:int@min(18)@max(120)"))
+
+	for _, want := range []string{
+		"final number = int.tryParse(input);",
+		"if (number < 18) {\n      return 'Age must be at least 18';",
+		"if (number > 120) {\n      return 'Age must be at most 120';",
+	} {
+		if !strings.Contains(validator, want) {
+			t.Errorf("validator does not contain %q:\n%s", want, validator)
+		}
+	}
+	if strings.Contains(validator, "input.length") {
+		t.Errorf("validator bounds the length of a number:\n%s", validator)
+	}
+}
+
+func TestFormValidatorBoundsTextByLength(t *testing.T) {
+	validator := formValidator(parseField(t, "password:String@min(8)"))
+
+	want := "if (input.length < 8) {\n      return 'Password must be at least 8 characters';"
+	if !strings.Contains(validator, want) {
+		t.Errorf("validator does not contain %q:\n%s", want, validator)
+	}
+}
+
+func TestFormValidatorChecksRequiredBool(t *testing.T) {
+	tests := map[string]bool{
+		"agree:bool@required": true,
+		"subscribe:bool":      false,
+	}
+	for spec, checked := range tests {
+		validator := formValidator(parseField(t, spec))
+		if !strings.Contains(validator, "(bool? value)") {
+			t.Errorf("%s: validator does not take a bool:\n%s", spec, validator)
+		}
+		if strings.Contains(validator, "value != true") != checked {
+			t.Errorf("%s: validator requires checking = %v, want %v:\n%s", spec, !checked, checked, validator)
+		}
+	}
+}
+
+func TestValidValuePassesValidators(t *testing.T) {
+	tests := map[string]string{
+		"age:int@min(18)":           "18",
+		"rate:double@max(0)":        "0",
+		"count:int?":                "1",
+		"agree:bool@required":       "true",
+		"email:String@email":        "'user@example.com'",
+		"code:String@min(8)":        "'aaavalue'",
+		"pin:String@max(4)":         "'valu'",
+		"name:String@min(2)@max(9)": "'value'",
+	}
+	for spec, want := range tests {
+		if got := validValue(parseField(t, spec)); got != want {
+			t.Errorf("validValue(%s) = %s, want %s", spec, got, want)
+		}
+	}
+}
+
+func TestFormStateUsesDeclaredTypes(t *testing.T) {
+	state, err := GenerateState("Signup", ScreenOptions{
+		Form: []utils.Field{
+			parseField(t, "email:String"),
+			parseField(t, "age:int"),
+			parseField(t, "agree:bool"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"final String email;", "final int? age;", "final bool agree;", "this.agree = false,"} {
+		if !strings.Contains(state, want) {
+			t.Errorf("state does not contain %q:\n%s", want, state)
+		}
+	}
+}
+
+func TestCheckFormFieldsRejectsUnsupportedTypes(t *testing.T) {
+	err := CheckFormFields([]utils.Field{parseField(t, "born:DateTime")})
+	if err == nil || !strings.Contains(err.Error(), "unsupported type DateTime on field born") {
+		t.Errorf("err = %v, want unsupported type DateTime", err)
+	}
+
+	err = CheckFormFields([]utils.Field{parseField(t, "agree:bool@min(1)")})
+	if err == nil {
+		t.Errorf("min is accepted on a bool field")
+	}
+}
@@ -0,0 +1,34 @@
+package templates
+
+import (
+	"flart/internal/utils"
+	"strings"
+)
+
+// injectionData is the context of the injection template
+type injectionData struct {
+	Context
+	Injectable bool
+	// ConfigFile is the file injectable generates next to the injection file
+	ConfigFile string
+	// Marker is the line new get_it registrations are inserted above
+	Marker string
+}
+
+// GenerateInjection creates the service locator setup file for get_it or injectable
+func GenerateInjection(injectable bool, fileName string) (string, error) {
+	return render("injection/injection.dart.tmpl", injectionData{
+		Context:    newContext("", nil, ""),
+		Injectable: injectable,
+		ConfigFile: strings.TrimSuffix(fileName, ".dart") + ".config.dart",
+		Marker:     utils.InjectionMarker,
+	})
+}
+
+// injectableAnnotation returns the injectable import and class annotation, or empty strings
+func injectableAnnotation(injectable bool, annotation string) (string, string) {
+	if !injectable {
+		return "", ""
+	}
+	return "import 'package:injectable/injectable.dart';\n", annotation + "\n"
+}
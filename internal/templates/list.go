@@ -0,0 +1,6 @@
+package templates
+
+// GenerateListTest creates bloc or cubit tests covering paging, hasReachedMax and failures
+func GenerateListTest(screenName string, opts ScreenOptions) (string, error) {
+	return render("list/test.dart.tmpl", newScreenData(screenName, opts))
+}
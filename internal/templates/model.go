@@ -2,166 +2,51 @@ package templates
 
 import (
 	"flart/internal/utils"
-	"fmt"
-	"strings"
 )
 
-// GenerateModel creates a Dart model class template with Equatable or Freezed implementation
-func GenerateModel(name string, useFreezed bool) string {
-	pascalName := utils.ToPascalCase(name)
-	snakeName := utils.ToSnakeCase(name)
-
-	if useFreezed {
-		return fmt.Sprintf(`
-import 'package:freezed_annotation/freezed_annotation.dart';
-
-part '%[1]s.freezed.dart';
-part '%[1]s.g.dart';
-
-@freezed
-abstract class %[2]s with _$%[2]s {
-    const factory %[2]s({
-        required String id,
-    }) = _%[2]s;
-
-    factory %[2]s.fromJson(Map<String, dynamic> json) => 
-        _$%[2]sFromJson(json);
-}`, snakeName, pascalName)
+// ModelOptions holds the settings used to generate a model and its test
+type ModelOptions struct {
+	UseFreezed bool
+	// Fields replaces the default id field when set
+	Fields []utils.Field
+	// Imports are package imports for field types declared in other files
+	Imports []string
+	// Samples maps field types to Dart expressions used in tests, e.g. enums and other models
+	Samples map[string]string
+	// TestImports are the package imports of the test, including the types used by the samples
+	TestImports []string
+}
+
+// modelData is the context of the model templates
+type modelData struct {
+	Context
+	UseFreezed  bool
+	Imports     []string
+	Samples     map[string]string
+	TestImports []string
+}
+
+// newModelData returns the template context of a model
+func newModelData(name string, opts ModelOptions, packageName string) modelData {
+	return modelData{
+		Context:    newContext(name, opts.Fields, packageName),
+		UseFreezed:  opts.UseFreezed,
+		Imports:     opts.Imports,
+		Samples:     opts.Samples,
+		TestImports: opts.TestImports,
 	}
+}
 
-	return fmt.Sprintf(`
-import 'package:equatable/equatable.dart';
-
-class %s extends Equatable {
-    final String id;
-
-    const %s({
-        required this.id,
-    });
-
-    @override
-    List<Object?> get props => [id];
-}`, pascalName, pascalName)
+// GenerateModel creates a Dart model class template with Equatable or Freezed implementation
+func GenerateModel(name string, opts ModelOptions) (string, error) {
+	return render("model/model.dart.tmpl", newModelData(name, opts, ""))
 }
 
-func GenerateModelTest(modelName string, useFreezed bool, projectDir string) string {
-	pascalName := utils.ToPascalCase(modelName)
-	snakeName := utils.ToSnakeCase(modelName)
+func GenerateModelTest(modelName string, opts ModelOptions, projectDir string) (string, error) {
 	packageName, err := utils.GetFlutterPackageName(projectDir)
 	if err != nil {
 		packageName = "flutter_app"
 	}
 
-	imports := []string{
-		"package:flutter_test/flutter_test.dart",
-		fmt.Sprintf("package:%s/models/%s.dart", packageName, snakeName),
-	}
-
-	// Common test cases for both Equatable and Freezed
-	testCases := []struct {
-		name     string
-		testCase string
-	}{
-		{"should create instance correctly", createInstanceTest(pascalName)},
-		{"should support value comparison", valueEqualityTest(pascalName)},
-		{"should have correct string representation", toStringTest(pascalName)},
-	}
-
-	// Add props test only for Equatable models
-	if !useFreezed {
-		testCases = append(testCases, struct {
-			name     string
-			testCase string
-		}{"should have correct props", propsTest(pascalName)})
-	}
-
-	var tests []string
-	for _, tc := range testCases {
-		tests = append(tests, fmt.Sprintf(`test('%s', () {
-            %s
-        });`, tc.name, tc.testCase))
-	}
-
-	// Add Freezed-specific tests
-	if useFreezed {
-		freezedTests := []struct {
-			name     string
-			testCase string
-		}{
-			{"should convert to and from JSON", jsonTest(pascalName)},
-			{"should support copyWith", copyWithTest(pascalName)},
-		}
-
-		for _, tc := range freezedTests {
-			tests = append(tests, fmt.Sprintf(`test('%s', () {
-                %s
-            });`, tc.name, tc.testCase))
-		}
-	}
-
-	return fmt.Sprintf(`import '%s';
-
-void main() {
-    group('%s', () {
-        %s
-    });
-}`, strings.Join(imports, "';\nimport '"), modelName, strings.Join(tests, "\n\n        "))
-}
-
-func createInstanceTest(modelName string) string {
-	return fmt.Sprintf(`final model = %s(
-        id: '1',
-    );
-    
-    expect(model.id, equals('1'));`, modelName)
-}
-
-func valueEqualityTest(modelName string) string {
-	return fmt.Sprintf(`final model1 = %s(
-        id: '1',
-    );
-    final model2 = %s(
-        id: '1',
-    );
-    
-    expect(model1, equals(model2));
-    expect(model1.hashCode, equals(model2.hashCode));`, modelName, modelName)
-}
-
-func propsTest(modelName string) string {
-	return fmt.Sprintf(`final model = %s(
-        id: '1',
-    );
-    
-    expect(model.props, equals([model.id]));`, modelName)
-}
-
-func toStringTest(modelName string) string {
-	return fmt.Sprintf(`final model = %s(
-        id: '1',
-    );
-    
-    expect(model.toString(), contains('%s'));
-    expect(model.toString(), contains('1'));`, modelName, modelName)
-}
-
-func jsonTest(modelName string) string {
-	return fmt.Sprintf(`final model = %s(
-        id: '1',
-    );
-    final json = model.toJson();
-    final fromJson = %s.fromJson(json);
-    
-    expect(fromJson, equals(model));
-    expect(json['id'], equals('1'));`, modelName, modelName)
-}
-
-func copyWithTest(modelName string) string {
-	return fmt.Sprintf(`final model = %s(
-        id: '1',
-    );
-    final copy = model.copyWith(id: '2');
-    
-    expect(copy.id, equals('2'));
-    expect(model.id, equals('1'));`, modelName)
+	return render("model/model_test.dart.tmpl", newModelData(modelName, opts, packageName))
 }
@@ -0,0 +1,27 @@
+package templates
+
+import (
+	"flart/internal/utils"
+	"fmt"
+	"strings"
+)
+
+// sampleValue returns the test value of a field, preferring an explicit sample
+func sampleValue(f utils.Field, samples map[string]string) string {
+	if sample, ok := samples[strings.TrimSuffix(f.Type, "?")]; ok {
+		return sample
+	}
+	return f.SampleValue()
+}
+
+// ModelSample renders a constructor call for a model, used as the sample of fields typed with it
+func ModelSample(name string, fields []utils.Field, samples map[string]string) string {
+	var args []string
+	for _, f := range fields {
+		if f.IsNullable() {
+			continue
+		}
+		args = append(args, fmt.Sprintf("%s: %s", f.Name, sampleValue(f, samples)))
+	}
+	return fmt.Sprintf("%s(%s)", utils.ToPascalCase(name), strings.Join(args, ", "))
+}
@@ -0,0 +1,247 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BuiltinPack is the name of the templates embedded in flart, used when no pack is selected
+const BuiltinPack = "builtin"
+
+// PackManifestFile is the manifest at the root of a template pack
+const PackManifestFile = "pack.yaml"
+
+// PacksDir holds the packs installed in a project, one directory per pack, relative to the project
+var PacksDir = filepath.Join(".flart", "packs")
+
+var (
+	packNamePattern      = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	packParameterPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Pack is a named set of templates replacing the embedded ones, described by its pack.yaml
+type Pack struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Version     string         `yaml:"version"`
+	Artifacts   []PackArtifact `yaml:"artifacts"`
+	// Dependencies and DevDependencies are added to the project when a pack artifact is generated
+	Dependencies    []string        `yaml:"dependencies"`
+	DevDependencies []string        `yaml:"devDependencies"`
+	Parameters      []PackParameter `yaml:"parameters"`
+	// Dir is the directory of the pack, empty for the built-in pack
+	Dir string `yaml:"-"`
+}
+
+// PackArtifact lists the templates a pack provides for one artifact, e.g. screen or model.
+// Files are template names, read from the templates directory of the pack.
+type PackArtifact struct {
+	Name  string   `yaml:"name"`
+	Files []string `yaml:"files"`
+}
+
+// PackParameter is a value templates read as .Params.<name>, set with packParams in the config
+type PackParameter struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Default     string `yaml:"default"`
+	Required    bool   `yaml:"required"`
+}
+
+// Builtin returns the embedded templates described as a pack, one artifact per template directory
+func Builtin() *Pack {
+	pack := &Pack{Name: BuiltinPack, Description: "Templates shipped with flart"}
+	for _, name := range TemplateNames() {
+		dir := path.Dir(name)
+		if n := len(pack.Artifacts); n == 0 || pack.Artifacts[n-1].Name != dir {
+			pack.Artifacts = append(pack.Artifacts, PackArtifact{Name: dir})
+		}
+		artifact := &pack.Artifacts[len(pack.Artifacts)-1]
+		artifact.Files = append(artifact.Files, name)
+	}
+	return pack
+}
+
+// LoadPack reads and validates the pack in dir
+func LoadPack(dir string) (*Pack, error) {
+	manifest := filepath.Join(dir, PackManifestFile)
+	content, err := os.ReadFile(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pack manifest: %w", err)
+	}
+
+	pack := &Pack{Dir: dir}
+	if err := yaml.Unmarshal(content, pack); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifest, err)
+	}
+	if err := pack.validate(); err != nil {
+		return nil, fmt.Errorf("invalid pack %s: %w", manifest, err)
+	}
+	return pack, nil
+}
+
+// validate checks the manifest against the embedded templates and the files of the pack
+func (p *Pack) validate() error {
+	if !packNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("invalid name %q, expected lowercase letters, digits, - and _", p.Name)
+	}
+	if p.Name == BuiltinPack {
+		return fmt.Errorf("the name %s is reserved for the embedded templates", BuiltinPack)
+	}
+	if len(p.Artifacts) == 0 {
+		return fmt.Errorf("no artifacts declared")
+	}
+
+	known := map[string]bool{}
+	for _, name := range TemplateNames() {
+		known[name] = true
+	}
+	seen := map[string]bool{}
+	for _, artifact := range p.Artifacts {
+		if seen[artifact.Name] {
+			return fmt.Errorf("artifact %s declared twice", artifact.Name)
+		}
+		seen[artifact.Name] = true
+		if len(artifact.Files) == 0 {
+			return fmt.Errorf("artifact %s has no files", artifact.Name)
+		}
+
+		for _, file := range artifact.Files {
+			if !known[file] {
+				return fmt.Errorf("unknown template %s, expected one of %s", file, strings.Join(templateAlternatives(file), ", "))
+			}
+			if path.Dir(file) != artifact.Name {
+				return fmt.Errorf("template %s does not belong to artifact %s", file, artifact.Name)
+			}
+			if _, err := os.Stat(p.templateFile(file)); err != nil {
+				return fmt.Errorf("missing template %s: %w", file, err)
+			}
+		}
+	}
+
+	params := map[string]bool{}
+	for _, param := range p.Parameters {
+		if !packParameterPattern.MatchString(param.Name) {
+			return fmt.Errorf("invalid parameter name %q", param.Name)
+		}
+		if params[param.Name] {
+			return fmt.Errorf("parameter %s declared twice", param.Name)
+		}
+		params[param.Name] = true
+	}
+	return nil
+}
+
+// templateFile returns the path of a template of the pack
+func (p *Pack) templateFile(name string) string {
+	return filepath.Join(p.Dir, "templates", filepath.FromSlash(name))
+}
+
+// Templates returns the names of the templates the pack provides, e.g. screen/bloc.dart.tmpl
+func (p *Pack) Templates() []string {
+	var names []string
+	for _, artifact := range p.Artifacts {
+		names = append(names, artifact.Files...)
+	}
+	return names
+}
+
+// Files returns the files of the pack relative to its directory: the manifest and the declared templates
+func (p *Pack) Files() []string {
+	files := []string{PackManifestFile}
+	for _, name := range p.Templates() {
+		files = append(files, filepath.Join("templates", filepath.FromSlash(name)))
+	}
+	return files
+}
+
+// ArtifactNames returns the artifacts the pack provides
+func (p *Pack) ArtifactNames() []string {
+	names := make([]string, len(p.Artifacts))
+	for i, artifact := range p.Artifacts {
+		names[i] = artifact.Name
+	}
+	return names
+}
+
+// params returns the parameter values of the pack, the defaults overridden by values
+func (p *Pack) params(values map[string]string) (map[string]string, error) {
+	params := map[string]string{}
+	declared := make([]string, len(p.Parameters))
+	for i, param := range p.Parameters {
+		declared[i] = param.Name
+		if value, ok := values[param.Name]; ok {
+			params[param.Name] = value
+		} else if param.Required {
+			return nil, fmt.Errorf("pack %s requires packParams.%s: %s", p.Name, param.Name, param.Description)
+		} else {
+			params[param.Name] = param.Default
+		}
+	}
+
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			return nil, fmt.Errorf("pack %s has no parameter %s, expected one of %s", p.Name, name, strings.Join(declared, ", "))
+		}
+	}
+	return params, nil
+}
+
+// InstalledPacks returns the packs installed in a project sorted by name. Packs that cannot
+// be loaded are returned as errors, so that one broken pack does not hide the others.
+func InstalledPacks(projectDir string) ([]*Pack, []error) {
+	entries, err := os.ReadDir(filepath.Join(projectDir, PacksDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{fmt.Errorf("failed to read installed packs: %w", err)}
+	}
+
+	var packs []*Pack
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pack, err := LoadPack(filepath.Join(projectDir, PacksDir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if pack.Name != entry.Name() {
+			errs = append(errs, fmt.Errorf("pack %s is installed as %s", pack.Name, entry.Name()))
+			continue
+		}
+		packs = append(packs, pack)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, errs
+}
+
+// findPack loads the installed pack with the given name
+func findPack(projectDir, name string) (*Pack, error) {
+	dir := filepath.Join(projectDir, PacksDir, name)
+	if !packNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid templatePack %q", name)
+	}
+	if _, err := os.Stat(filepath.Join(dir, PackManifestFile)); os.IsNotExist(err) {
+		return nil, fmt.Errorf("template pack %s is not installed, install it with flart pack install", name)
+	}
+
+	pack, err := LoadPack(dir)
+	if err != nil {
+		return nil, err
+	}
+	if pack.Name != name {
+		return nil, fmt.Errorf("pack %s is installed as %s", pack.Name, name)
+	}
+	return pack, nil
+}
@@ -0,0 +1,300 @@
+package templates
+
+import (
+	"embed"
+	"flart/internal/config"
+	"flart/internal/utils"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// embedded holds the default templates, one .tmpl file per generated file. Files starting with
+// an underscore are partials shared by the templates of their directory.
+//
+//go:embed all:files
+var embedded embed.FS
+
+// OverrideDir is where a project keeps templates replacing the embedded ones, relative to the project
+var OverrideDir = filepath.Join(".flart", "templates")
+
+// Names holds an artifact name in every case used by generated code
+type Names struct {
+	// Raw is the name as given on the command line
+	Raw    string
+	Pascal string
+	Camel  string
+	Snake  string
+	Kebab  string
+}
+
+// NewNames converts name into every case
+func NewNames(name string) Names {
+	snake := utils.ToSnakeCase(name)
+	return Names{
+		Raw:    name,
+		Pascal: utils.ToPascalCase(name),
+		Camel:  utils.ToCamelCase(name),
+		Snake:  snake,
+		Kebab:  strings.ReplaceAll(snake, "_", "-"),
+	}
+}
+
+// Context is the data shared by every template
+type Context struct {
+	Name   Names
+	Fields []utils.Field
+	// PackageName is the Dart package of the project, empty when the generator does not need it
+	PackageName string
+	// Config is the project configuration, nil when templates are rendered outside of a project
+	Config *config.Config
+	// Params holds the parameters of the selected template pack, see Pack.Parameters
+	Params map[string]string
+}
+
+var (
+	// projectConfig is the configuration passed to templates
+	projectConfig *config.Config
+	// projectPackage is the Dart package of the project, for generators that are not given one
+	projectPackage string
+	// loaded holds the embedded templates and the project overrides, parsed on first use
+	loaded *template.Template
+	// activePack is the selected template pack, nil for the built-in templates
+	activePack *Pack
+	// packParams holds the parameter values of the selected pack
+	packParams map[string]string
+	// packUsed is set once an artifact provided by the selected pack is rendered
+	packUsed bool
+)
+
+// newContext returns the shared template data for an artifact
+func newContext(name string, fields []utils.Field, packageName string) Context {
+	if packageName == "" {
+		packageName = projectPackage
+	}
+	return Context{Name: NewNames(name), Fields: fields, PackageName: packageName, Config: projectConfig, Params: packParams}
+}
+
+// funcs are the helpers available to every template
+var funcs = template.FuncMap{
+	"pascal":      utils.ToPascalCase,
+	"camel":       utils.ToCamelCase,
+	"snake":       utils.ToSnakeCase,
+	"kebab":       func(s string) string { return strings.ReplaceAll(utils.ToSnakeCase(s), "_", "-") },
+	"lower":       strings.ToLower,
+	"upper":       strings.ToUpper,
+	"join":        func(sep string, items []string) string { return strings.Join(items, sep) },
+	"contains":    strings.Contains,
+	"nonNullable": func(dartType string) string { return strings.TrimSuffix(dartType, "?") },
+	"label":       fieldLabel,
+	"validator":   formValidator,
+	"validates": func(f utils.Field, name string) bool {
+		ok, _ := hasValidator(f, name)
+		return ok
+	},
+	"isRequired":   isRequired,
+	"numeric":      isNumeric,
+	"isBool":       isBool,
+	"stateType":    stateType,
+	"stateDefault": stateDefault,
+	"validValue":   validValue,
+	"sample":       func(f utils.Field) string { return f.SampleValue() },
+	"sampleOf":     sampleValue,
+}
+
+// Configure loads the templates of a project. The templates of the pack selected by templatePack
+// replace the embedded ones, and files under .flart/templates replace both when they have the
+// same relative path, e.g. .flart/templates/screen/bloc.dart.tmpl. Templates of custom
+// generators are also read from .flart/templates.
+func Configure(projectDir string, cfg *config.Config) error {
+	projectConfig = cfg
+	projectPackage, _ = utils.GetFlutterPackageName(projectDir)
+	activePack, packParams, packUsed = nil, nil, false
+
+	set, err := parseEmbedded()
+	if err != nil {
+		return err
+	}
+
+	if cfg != nil && cfg.TemplatePack != nil && *cfg.TemplatePack != BuiltinPack {
+		if err := loadPack(set, projectDir, *cfg.TemplatePack, cfg.PackParams); err != nil {
+			return fmt.Errorf("failed to load template pack: %w", err)
+		}
+	}
+
+	overrideDir := filepath.Join(projectDir, OverrideDir)
+	custom := customTemplates(cfg)
+	err = filepath.WalkDir(overrideDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && file == overrideDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(file, ".tmpl") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(overrideDir, file)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if set.Lookup(name) == nil && !custom[name] {
+			return fmt.Errorf("unknown template %s, expected one of %s", file, strings.Join(templateAlternatives(name), ", "))
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		return parseTemplate(set, name, string(content))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	loaded = set
+	return nil
+}
+
+// loadPack parses the templates of an installed pack into set and selects it
+func loadPack(set *template.Template, projectDir, name string, values map[string]string) error {
+	pack, err := findPack(projectDir, name)
+	if err != nil {
+		return err
+	}
+	params, err := pack.params(values)
+	if err != nil {
+		return err
+	}
+
+	for _, artifact := range pack.Artifacts {
+		for _, file := range artifact.Files {
+			content, err := os.ReadFile(pack.templateFile(file))
+			if err != nil {
+				return err
+			}
+			if err := parseTemplate(set, file, string(content)); err != nil {
+				return err
+			}
+		}
+	}
+
+	activePack, packParams = pack, params
+	return nil
+}
+
+// SelectedPack returns the pack loaded by Configure, nil for the built-in templates
+func SelectedPack() *Pack {
+	return activePack
+}
+
+// PackDependencies returns and clears the dependencies of the selected pack once one of its
+// artifacts was rendered, so that generators add them along with their own
+func PackDependencies() (dependencies, devDependencies []string) {
+	if activePack == nil || !packUsed {
+		return nil, nil
+	}
+	packUsed = false
+	return activePack.Dependencies, activePack.DevDependencies
+}
+
+// TemplateNames returns the names of the embedded templates, which are also their override paths
+func TemplateNames() []string {
+	var names []string
+	fs.WalkDir(embedded, "files", func(file string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() && strings.HasSuffix(file, ".tmpl") {
+			names = append(names, strings.TrimPrefix(file, "files/"))
+		}
+		return nil
+	})
+	return names
+}
+
+// templateAlternatives returns the templates in the directory of name, or the template directories
+// when there is no such directory
+func templateAlternatives(name string) []string {
+	var names, dirs []string
+	seen := map[string]bool{}
+	for _, candidate := range TemplateNames() {
+		dir := path.Dir(candidate)
+		if dir == path.Dir(name) {
+			names = append(names, candidate)
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir+"/")
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
+	return dirs
+}
+
+// embeddedTemplate returns the content of an embedded template
+func embeddedTemplate(name string) (string, error) {
+	content, err := embedded.ReadFile(path.Join("files", name))
+	if err != nil {
+		return "", fmt.Errorf("unknown template %s", name)
+	}
+	return string(content), nil
+}
+
+// parseEmbedded parses every embedded template into a new set
+func parseEmbedded() (*template.Template, error) {
+	set := template.New("").Funcs(funcs)
+	for _, name := range TemplateNames() {
+		content, err := embeddedTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := parseTemplate(set, name, content); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// parseTemplate adds a template to set. A single final newline is dropped, so that template
+// files can end with a newline without adding one to the generated file.
+func parseTemplate(set *template.Template, name, content string) error {
+	content = strings.TrimSuffix(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if _, err := set.New(name).Parse(content); err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	return nil
+}
+
+// templateSet returns the loaded templates, parsing the embedded ones when Configure was not called
+func templateSet() *template.Template {
+	if loaded == nil {
+		set, err := parseEmbedded()
+		if err != nil {
+			panic(err)
+		}
+		loaded = set
+	}
+	return loaded
+}
+
+// render executes a template with the given data
+func render(name string, data any) (string, error) {
+	set := templateSet()
+	if activePack != nil && !packUsed {
+		for _, artifact := range activePack.Artifacts {
+			packUsed = packUsed || artifact.Name == path.Dir(name)
+		}
+	}
+
+	var b strings.Builder
+	if err := set.ExecuteTemplate(&b, name, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,22 @@
+package templates
+
+import (
+	"fmt"
+)
+
+// repositoryData is the context of the repository template
+type repositoryData struct {
+	Context
+	// Model names the model handled by the CRUD methods, empty for a bare repository
+	Model Names
+	// InjectableImport and Annotation register the implementation with injectable when enabled
+	InjectableImport string
+	Annotation       string
+}
+
+// GenerateRepository creates an abstract repository and its implementation, with CRUD methods when a model is given
+func GenerateRepository(name, model, packageName string, injectable bool) (string, error) {
+	data := repositoryData{Context: newContext(name, nil, packageName), Model: NewNames(model)}
+	data.InjectableImport, data.Annotation = injectableAnnotation(injectable, fmt.Sprintf("@LazySingleton(as: %sRepository)", data.Name.Pascal))
+	return render("repository/repository.dart.tmpl", data)
+}
@@ -0,0 +1,17 @@
+package templates
+
+import (
+	"flart/internal/utils"
+)
+
+// routerData is the context of the router template
+type routerData struct {
+	Context
+	// Marker is the line new routes are inserted above
+	Marker string
+}
+
+// GenerateRouter creates the go_router configuration file
+func GenerateRouter() (string, error) {
+	return render("router/router.dart.tmpl", routerData{Context: newContext("", nil, ""), Marker: utils.RoutesMarker})
+}
@@ -3,196 +3,218 @@ package templates
 import (
 	"flart/internal/utils"
 	"fmt"
-	"strings"
 )
 
-// GenerateScreen creates a Flutter screen template with BLoC or Cubit integration
-func GenerateScreen(screenName string, useCubit bool) string {
-	// Convert to PascalCase
-	pascalName := utils.ToPascalCase(screenName)
-	snakeName := utils.ToSnakeCase(screenName)
-
-	if useCubit {
-		return fmt.Sprintf(`import 'package:flutter/material.dart';
-import 'package:flutter_bloc/flutter_bloc.dart';
-
-import 'cubit/%[1]s_cubit.dart';
-import 'cubit/%[1]s_state.dart';
-
-class %[2]sScreen extends StatelessWidget {
-  const %[2]sScreen({super.key});
-
-  @override
-  Widget build(BuildContext context) {
-    return BlocProvider(
-      create: (context) => %[2]sCubit(),
-      child: const %[2]sView(),
-    );
-  }
-}
-
-class %[2]sView extends StatefulWidget {
-  const %[2]sView({super.key});
-
-  @override
-  State<%[2]sView> createState() => _%[2]sViewState();
-}
-
-class _%[2]sViewState extends State<%[2]sView> {
-  @override
-  Widget build(BuildContext context) {
-    return Scaffold(
-      appBar: AppBar(title: const Text('%[2]s')),
-      body: BlocBuilder<%[2]sCubit, %[2]sState>(
-        builder: (context, state) {
-          return const Center(child: Text('%[2]s Screen'));
-        },
-      ),
-    );
-  }
-}`, snakeName, pascalName)
-	}
-	return fmt.Sprintf(`import 'package:flutter/material.dart';
-import 'package:flutter_bloc/flutter_bloc.dart';
+// ScreenOptions describes the screen variant to generate
+type ScreenOptions struct {
+	UseCubit   bool
+	UseFreezed bool
+	Injectable bool
+	// DIImport resolves the bloc or cubit from the service locator when set
+	DIImport string
+	// Form generates a form with one text field per entry
+	Form []utils.Field
+	// ListItem generates a paginated list of the named model
+	ListItem string
+	// ItemSample is the list item used by the list tests, with the ItemImports it needs.
+	// It defaults to the item model with only an id field.
+	ItemSample  string
+	ItemImports []string
+	// PackageName is used for package imports, e.g. of the list item model
+	PackageName string
+	// L10nImport localizes screen strings through AppLocalizations when set
+	L10nImport string
+}
 
-import 'bloc/%[1]s_bloc.dart';
-import 'bloc/%[1]s_event.dart';
-import 'bloc/%[1]s_state.dart';
+// blocProvider returns the extra screen imports and the expression that creates the bloc or cubit
+func blocProvider(pascalName string, opts ScreenOptions) (string, string) {
+	suffix := "Bloc"
+	if opts.UseCubit {
+		suffix = "Cubit"
+	}
 
-class %[2]sScreen extends StatelessWidget {
-  const %[2]sScreen({super.key});
+	var imports string
+	if opts.DIImport != "" {
+		imports += opts.DIImport + "\n"
+	}
+	if opts.L10nImport != "" {
+		imports += fmt.Sprintf("import '%s';\n", opts.L10nImport)
+	}
 
-  @override
-  Widget build(BuildContext context) {
-    return BlocProvider(
-      create: (context) => %[2]sBloc()..add(const %[2]sInitialEvent()),
-      child: const %[2]sView(),
-    );
-  }
+	if opts.DIImport == "" {
+		return imports, pascalName + suffix + "()"
+	}
+	return imports, fmt.Sprintf("sl<%s%s>()", pascalName, suffix)
 }
 
-class %[2]sView extends StatefulWidget {
-  const %[2]sView({super.key});
-
-  @override
-  State<%[2]sView> createState() => _%[2]sViewState();
+// screenTitle renders the app bar title, localized when l10n is enabled
+func screenTitle(pascalName string, opts ScreenOptions) string {
+	if opts.L10nImport == "" {
+		return fmt.Sprintf("const Text('%s')", pascalName)
+	}
+	return fmt.Sprintf("Text(AppLocalizations.of(context)!.%sTitle)", utils.ToCamelCase(pascalName))
 }
 
-class _%[2]sViewState extends State<%[2]sView> {
-  @override
-  Widget build(BuildContext context) {
-    return Scaffold(
-      appBar: AppBar(title: const Text('%[2]s')),
-      body: BlocBuilder<%[2]sBloc, %[2]sState>(
-        builder: (context, state) {
-          return const Center(child: Text('%[2]s Screen'));
-        },
-      ),
-    );
-  }
-}`, snakeName, pascalName)
+// screenBody renders the placeholder body, localized when l10n is enabled
+func screenBody(pascalName string, opts ScreenOptions) string {
+	if opts.L10nImport == "" {
+		return fmt.Sprintf("const Center(child: Text('%s Screen'))", pascalName)
+	}
+	return fmt.Sprintf("Center(child: Text(AppLocalizations.of(context)!.%sBody))", utils.ToCamelCase(pascalName))
 }
 
-// GenerateBloc creates a BLoC template with initial setup
-func GenerateBloc(screenName string) string {
-	pascalName := utils.ToPascalCase(screenName)
-	snakeName := utils.ToSnakeCase(screenName)
-	return fmt.Sprintf(`import 'package:flutter_bloc/flutter_bloc.dart';
-
-import '%[1]s_event.dart';
-import '%[1]s_state.dart';
-
-class %[2]sBloc extends Bloc<%[2]sEvent, %[2]sState> {
-  %[2]sBloc() : super(const %[2]sState()) {
-    on<%[2]sInitialEvent>(_onInitial);
-  }
-
-  Future<void> _onInitial(
-    %[2]sInitialEvent event,
-    Emitter<%[2]sState> emit,
-  ) async {
-    // TODO: Add your logic here
-  }
-}`, snakeName, pascalName)
+// screenStrings returns the strings shown by form and list screens, keyed by the suffix of their
+// ARB key, e.g. submit for loginSubmit
+func screenStrings(opts ScreenOptions) []utils.ArbEntry {
+	var entries []utils.ArbEntry
+	if len(opts.Form) > 0 {
+		for _, f := range opts.Form {
+			entries = append(entries, utils.ArbEntry{Key: f.Name + "Label", Value: fieldLabel(f)})
+		}
+		entries = append(entries,
+			utils.ArbEntry{Key: "submit", Value: "Submit"},
+			utils.ArbEntry{Key: "submissionFailed", Value: "Submission failed"})
+	} else if opts.ListItem != "" {
+		entries = append(entries,
+			utils.ArbEntry{Key: "error", Value: "Something went wrong"},
+			utils.ArbEntry{Key: "retry", Value: "Retry"},
+			utils.ArbEntry{Key: "empty", Value: "Nothing here yet"})
+	}
+	return entries
 }
 
-// GenerateCubit creates a Cubit template with initial setup
-func GenerateCubit(screenName string) string {
-	pascalName := utils.ToPascalCase(screenName)
-	snakeName := utils.ToSnakeCase(screenName)
-	return fmt.Sprintf(`import 'package:flutter_bloc/flutter_bloc.dart';
-
-import '%[1]s_state.dart';
+// screenTexts maps the keys of screen strings to the Dart expressions showing them, either string
+// literals or AppLocalizations getters prefixed with the screen name
+func screenTexts(screenName string, entries []utils.ArbEntry, localized bool) map[string]string {
+	texts := make(map[string]string, len(entries))
+	for _, s := range entries {
+		if localized {
+			texts[s.Key] = fmt.Sprintf("AppLocalizations.of(context)!.%s%s", utils.ToCamelCase(screenName), utils.ToPascalCase(s.Key))
+		} else {
+			texts[s.Key] = fmt.Sprintf("'%s'", s.Value)
+		}
+	}
+	return texts
+}
 
-class %[2]sCubit extends Cubit<%[2]sState> {
-  %[2]sCubit() : super(const %[2]sState());
+// prefixedEntries prefixes the keys of screen strings with the screen name for the ARB files
+func prefixedEntries(screenName string, entries []utils.ArbEntry) []utils.ArbEntry {
+	prefixed := make([]utils.ArbEntry, len(entries))
+	for i, s := range entries {
+		prefixed[i] = utils.ArbEntry{Key: utils.ToCamelCase(screenName) + utils.ToPascalCase(s.Key), Value: s.Value}
+	}
+	return prefixed
+}
 
-  Future<void> init() async {
-    // TODO: Add your logic here
-  }
-}`, snakeName, pascalName)
+// constPrefix returns "const " for widgets that are only constant without localized strings
+func constPrefix(localized bool) string {
+	if localized {
+		return ""
+	}
+	return "const "
 }
 
-// GenerateEvent creates event classes for the BLoC
-func GenerateEvent(screenName string) string {
+// ScreenL10nEntries returns the ARB entries used by a localized screen
+func ScreenL10nEntries(screenName string, opts ScreenOptions) []utils.ArbEntry {
 	pascalName := utils.ToPascalCase(screenName)
-	return fmt.Sprintf(`
-import 'package:equatable/equatable.dart';
-
-abstract class %[2]sEvent extends Equatable {
-  const %[2]sEvent();
-
-  @override
-  List<Object> get props => [];
-}
+	camelName := utils.ToCamelCase(screenName)
 
-class %[2]sInitialEvent extends %[2]sEvent {
-  const %[2]sInitialEvent();
+	entries := []utils.ArbEntry{{Key: camelName + "Title", Value: pascalName}}
+	if len(opts.Form) == 0 && opts.ListItem == "" {
+		entries = append(entries, utils.ArbEntry{Key: camelName + "Body", Value: pascalName + " Screen"})
+	}
+	return append(entries, prefixedEntries(screenName, screenStrings(opts))...)
 }
 
-class %[2]sRefreshEvent extends %[2]sEvent {
-  const %[2]sRefreshEvent();
-}`, strings.ToLower(screenName), pascalName)
+// screenData is the context of the screen, bloc, cubit, event, state and test templates
+type screenData struct {
+	Context
+	UseCubit   bool
+	UseFreezed bool
+	Injectable bool
+	// Item names the model listed by list screens
+	Item Names
+	// ItemSample and ItemImports build a list item in the list tests
+	ItemSample  string
+	ItemImports []string
+	// Imports are the service locator and localization imports of the screen, each on its own line
+	Imports string
+	// Create is the expression that creates the bloc or cubit
+	Create string
+	// Title and Body are the app bar title and placeholder body, localized when l10n is enabled
+	Title string
+	Body  string
+	// InjectableImport and Annotation register the bloc or cubit with injectable when enabled
+	InjectableImport string
+	Annotation       string
+	// Text maps the keys of screenStrings to the Dart expressions showing them
+	Text map[string]string
+	// Const is "const " unless the strings are localized, for widgets holding them
+	Const string
 }
 
-// GenerateState creates state classes for the BLoC or Cubit
-func GenerateState(screenName string, useCubit bool, useFreezed bool) string {
+// newScreenData returns the template context of a screen
+func newScreenData(screenName string, opts ScreenOptions) screenData {
 	pascalName := utils.ToPascalCase(screenName)
-	snakeName := utils.ToSnakeCase(screenName)
-
-	if useFreezed {
-		return fmt.Sprintf(`
-import 'package:freezed_annotation/freezed_annotation.dart';
+	imports, create := blocProvider(pascalName, opts)
+	injectableImport, annotation := injectableAnnotation(opts.Injectable, "@injectable")
+	item := NewNames(opts.ListItem)
+	if opts.ListItem != "" && opts.ItemSample == "" {
+		opts.ItemSample = fmt.Sprintf("%s(id: 'id')", item.Pascal)
+		opts.ItemImports = []string{fmt.Sprintf("package:%s/models/%s.dart", opts.PackageName, item.Snake)}
+	}
 
-part '%[1]s_state.freezed.dart';
+	return screenData{
+		Context:          newContext(screenName, opts.Form, opts.PackageName),
+		UseCubit:         opts.UseCubit,
+		UseFreezed:       opts.UseFreezed,
+		Injectable:       opts.Injectable,
+		Item:             item,
+		Text:             screenTexts(screenName, screenStrings(opts), opts.L10nImport != ""),
+		Const:            constPrefix(opts.L10nImport != ""),
+		ItemSample:       opts.ItemSample,
+		ItemImports:      opts.ItemImports,
+		Imports:          imports,
+		Create:           create,
+		Title:            screenTitle(pascalName, opts),
+		Body:             screenBody(pascalName, opts),
+		InjectableImport: injectableImport,
+		Annotation:       annotation,
+	}
+}
 
-@freezed
-abstract class %[2]sState with _$%[2]sState {
-  const factory %[2]sState({
-    @Default(false) bool isLoading,
-  }) = _%[2]sState;
-}`, snakeName, pascalName)
+// renderScreen renders a screen file from the templates of its variant: form, list or screen
+func renderScreen(file, screenName string, opts ScreenOptions) (string, error) {
+	variant := "screen"
+	if len(opts.Form) > 0 {
+		variant = "form"
+	} else if opts.ListItem != "" {
+		variant = "list"
 	}
+	return render(variant+"/"+file, newScreenData(screenName, opts))
+}
 
-	return fmt.Sprintf(`
-import 'package:equatable/equatable.dart';
+// GenerateScreen creates a Flutter screen template with BLoC or Cubit integration
+func GenerateScreen(screenName string, opts ScreenOptions) (string, error) {
+	return renderScreen("screen.dart.tmpl", screenName, opts)
+}
 
-class %[2]sState extends Equatable {
-  final bool isLoading;
+// GenerateBloc creates a BLoC template with initial setup
+func GenerateBloc(screenName string, opts ScreenOptions) (string, error) {
+	return renderScreen("bloc.dart.tmpl", screenName, opts)
+}
 
-  const %[2]sState({
-    this.isLoading = false,
-  });
+// GenerateCubit creates a Cubit template with initial setup
+func GenerateCubit(screenName string, opts ScreenOptions) (string, error) {
+	return renderScreen("cubit.dart.tmpl", screenName, opts)
+}
 
-  @override
-  List<Object?> get props => [isLoading];
+// GenerateEvent creates event classes for the BLoC
+func GenerateEvent(screenName string, opts ScreenOptions) (string, error) {
+	return renderScreen("event.dart.tmpl", screenName, opts)
+}
 
-  %[2]sState copyWith({
-    bool? isLoading,
-  }) {
-    return %[2]sState(
-      isLoading: isLoading ?? this.isLoading,
-    );
-  }
-}`, snakeName, pascalName)
+// GenerateState creates state classes for the BLoC or Cubit
+func GenerateState(screenName string, opts ScreenOptions) (string, error) {
+	return renderScreen("state.dart.tmpl", screenName, opts)
 }
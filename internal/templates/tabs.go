@@ -0,0 +1,76 @@
+package templates
+
+import (
+	"flart/internal/utils"
+)
+
+// TabsOptions describes the tabbed shell screen to generate
+type TabsOptions struct {
+	Tabs []string
+	// TopTabs uses a TabBar in the app bar instead of a BottomNavigationBar
+	TopTabs bool
+	// GoRouter builds the shell as a go_router StatefulShellRoute
+	GoRouter bool
+	// L10nImport localizes the title and the tab labels through AppLocalizations when set
+	L10nImport string
+}
+
+// tabsData is the context of the tabs templates
+type tabsData struct {
+	Context
+	// Tabs names the child screen of each tab
+	Tabs     []Names
+	TopTabs  bool
+	GoRouter bool
+	// L10nImport is the AppLocalizations import, empty when l10n is disabled
+	L10nImport string
+	// Title is the app bar title of top tabs
+	Title string
+	// Text maps the keys of tabsStrings to the Dart expressions showing them
+	Text map[string]string
+	// Const is "const " unless the strings are localized, for widgets holding them
+	Const string
+}
+
+// tabsStrings returns the tab labels of a tabbed screen, keyed by the suffix of their ARB key,
+// e.g. feedTab for homeFeedTab
+func tabsStrings(opts TabsOptions) []utils.ArbEntry {
+	entries := make([]utils.ArbEntry, len(opts.Tabs))
+	for i, tab := range opts.Tabs {
+		names := NewNames(tab)
+		entries[i] = utils.ArbEntry{Key: names.Camel + "Tab", Value: names.Pascal}
+	}
+	return entries
+}
+
+// TabsL10nEntries returns the ARB entries used by a localized tabbed screen
+func TabsL10nEntries(screenName string, opts TabsOptions) []utils.ArbEntry {
+	var entries []utils.ArbEntry
+	if opts.TopTabs {
+		entries = append(entries, utils.ArbEntry{Key: utils.ToCamelCase(screenName) + "Title", Value: utils.ToPascalCase(screenName)})
+	}
+	return append(entries, prefixedEntries(screenName, tabsStrings(opts))...)
+}
+
+// GenerateTabCubit creates a cubit holding the selected tab index
+func GenerateTabCubit(screenName string) (string, error) {
+	return render("tabs/tab_cubit.dart.tmpl", tabsData{Context: newContext(screenName, nil, "")})
+}
+
+// GenerateTabsScreen creates a shell screen switching between one child screen per tab
+func GenerateTabsScreen(screenName string, opts TabsOptions) (string, error) {
+	localized := opts.L10nImport != ""
+	data := tabsData{
+		Context:    newContext(screenName, nil, ""),
+		TopTabs:    opts.TopTabs,
+		GoRouter:   opts.GoRouter,
+		L10nImport: opts.L10nImport,
+		Title:      screenTitle(utils.ToPascalCase(screenName), ScreenOptions{L10nImport: opts.L10nImport}),
+		Text:       screenTexts(screenName, tabsStrings(opts), localized),
+		Const:      constPrefix(localized),
+	}
+	for _, tab := range opts.Tabs {
+		data.Tabs = append(data.Tabs, NewNames(tab))
+	}
+	return render("tabs/screen.dart.tmpl", data)
+}
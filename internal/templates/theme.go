@@ -0,0 +1,223 @@
+package templates
+
+import (
+	"flart/internal/utils"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// colorSchemeRoles are the ColorScheme parameters that color tokens can override
+var colorSchemeRoles = map[string]bool{
+	"primary": true, "onPrimary": true, "primaryContainer": true, "onPrimaryContainer": true,
+	"secondary": true, "onSecondary": true, "secondaryContainer": true, "onSecondaryContainer": true,
+	"tertiary": true, "onTertiary": true, "tertiaryContainer": true, "onTertiaryContainer": true,
+	"error": true, "onError": true, "errorContainer": true, "onErrorContainer": true,
+	"surface": true, "onSurface": true, "onSurfaceVariant": true, "surfaceTint": true,
+	"outline": true, "outlineVariant": true, "shadow": true, "scrim": true,
+	"inverseSurface": true, "onInverseSurface": true, "inversePrimary": true,
+}
+
+// textThemeRoles are the TextTheme parameters that typography tokens can fill
+var textThemeRoles = map[string]bool{
+	"displayLarge": true, "displayMedium": true, "displaySmall": true,
+	"headlineLarge": true, "headlineMedium": true, "headlineSmall": true,
+	"titleLarge": true, "titleMedium": true, "titleSmall": true,
+	"bodyLarge": true, "bodyMedium": true, "bodySmall": true,
+	"labelLarge": true, "labelMedium": true, "labelSmall": true,
+}
+
+// tokenIdentifier converts a token name into a Dart identifier, prefixing names that start with a digit
+func tokenIdentifier(name, prefix string) string {
+	identifier := utils.ToCamelCase(name)
+	if identifier == "" || (identifier[0] >= '0' && identifier[0] <= '9') {
+		return prefix + utils.ToPascalCase(identifier)
+	}
+	return identifier
+}
+
+// formatNumber renders a token number without trailing zeros
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// themeConstant is a static constant of a generated token class
+type themeConstant struct {
+	Name  string
+	Value string
+}
+
+// themeCustomColor is a color of the AppColorTokens extension with its constant in each mode
+type themeCustomColor struct {
+	Name  string
+	Light string
+	Dark  string
+}
+
+// themeData is the context of the theme template
+type themeData struct {
+	Context
+	// Source is the tokens file the theme was generated from
+	Source       string
+	Colors       []themeConstant
+	Spacing      []themeConstant
+	Typography   []themeConstant
+	CustomColors []themeCustomColor
+	// TextRoles are the typography tokens named after TextTheme parameters
+	TextRoles   []string
+	LightScheme string
+	DarkScheme  string
+}
+
+// themeColors resolves the AppColors constant for each color name in both modes
+type themeColors struct {
+	tokens    *utils.DesignTokens
+	light     map[string]string
+	dark      map[string]string
+	constants []themeConstant
+}
+
+func newThemeColors(tokens *utils.DesignTokens) *themeColors {
+	c := &themeColors{tokens: tokens, light: map[string]string{}, dark: map[string]string{}}
+
+	if tokens.SharedColors {
+		for _, color := range tokens.LightColors {
+			name := tokenIdentifier(color.Name, "color")
+			c.constants = append(c.constants, themeConstant{Name: name, Value: fmt.Sprintf("Color(0x%08X)", color.ARGB)})
+			c.light[utils.ToCamelCase(color.Name)] = "AppColors." + name
+			c.dark[utils.ToCamelCase(color.Name)] = "AppColors." + name
+		}
+		return c
+	}
+
+	for _, color := range tokens.LightColors {
+		name := "light" + utils.ToPascalCase(color.Name)
+		c.constants = append(c.constants, themeConstant{Name: name, Value: fmt.Sprintf("Color(0x%08X)", color.ARGB)})
+		c.light[utils.ToCamelCase(color.Name)] = "AppColors." + name
+	}
+	for _, color := range tokens.DarkColors {
+		name := "dark" + utils.ToPascalCase(color.Name)
+		c.constants = append(c.constants, themeConstant{Name: name, Value: fmt.Sprintf("Color(0x%08X)", color.ARGB)})
+		c.dark[utils.ToCamelCase(color.Name)] = "AppColors." + name
+	}
+	return c
+}
+
+// lookup returns the constant for a color in a mode, falling back to the other mode
+func (c *themeColors) lookup(name string, dark bool) string {
+	primary, fallback := c.light, c.dark
+	if dark {
+		primary, fallback = c.dark, c.light
+	}
+	if value, ok := primary[name]; ok {
+		return value
+	}
+	return fallback[name]
+}
+
+// names returns the sorted camelCase names of every color in either mode
+func (c *themeColors) names() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, color := range append(append([]utils.ColorToken{}, c.tokens.LightColors...), c.tokens.DarkColors...) {
+		name := utils.ToCamelCase(color.Name)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// colorScheme renders the ColorScheme for a mode, seeded from the primary color when present
+func (c *themeColors) colorScheme(dark bool) string {
+	brightness, fallback, own := "light", "const ColorScheme.light()", c.light
+	if dark {
+		brightness, fallback, own = "dark", "const ColorScheme.dark()", c.dark
+	}
+
+	scheme := fallback
+	if seed := c.lookup("primary", dark); seed != "" {
+		scheme = fmt.Sprintf("ColorScheme.fromSeed(seedColor: %s, brightness: Brightness.%s)", seed, brightness)
+	}
+
+	// Roles missing from this mode are derived from the seed rather than borrowed
+	var overrides []string
+	for _, name := range c.names() {
+		if value, ok := own[name]; ok && colorSchemeRoles[name] {
+			overrides = append(overrides, fmt.Sprintf("%s: %s,", name, value))
+		}
+	}
+	if len(overrides) == 0 {
+		return scheme
+	}
+	return fmt.Sprintf("%s.copyWith(\n        %s\n      )", scheme, strings.Join(overrides, "\n        "))
+}
+
+// customColors returns the color names that are not ColorScheme roles
+func (c *themeColors) customColors() []string {
+	var custom []string
+	for _, name := range c.names() {
+		if !colorSchemeRoles[name] {
+			custom = append(custom, name)
+		}
+	}
+	return custom
+}
+
+// generateTextStyle renders a const TextStyle for a typography token
+func generateTextStyle(token utils.TextToken) string {
+	var args []string
+	if token.FontFamily != "" {
+		args = append(args, fmt.Sprintf("fontFamily: '%s'", strings.ReplaceAll(token.FontFamily, "'", "\\'")))
+	}
+	if token.FontSize != nil {
+		args = append(args, "fontSize: "+formatNumber(*token.FontSize))
+	}
+	if token.FontWeight != nil {
+		args = append(args, fmt.Sprintf("fontWeight: FontWeight.w%d", *token.FontWeight))
+	}
+	if token.Height != nil {
+		args = append(args, "height: "+formatNumber(*token.Height))
+	}
+	if token.LetterSpacing != nil {
+		args = append(args, "letterSpacing: "+formatNumber(*token.LetterSpacing))
+	}
+	return fmt.Sprintf("TextStyle(%s)", strings.Join(args, ", "))
+}
+
+// GenerateTheme creates typed token constants, a color ThemeExtension and light and dark ThemeData
+func GenerateTheme(tokens *utils.DesignTokens, source string) (string, error) {
+	colors := newThemeColors(tokens)
+	data := themeData{
+		Context:     newContext("", nil, ""),
+		Source:      source,
+		Colors:      colors.constants,
+		LightScheme: colors.colorScheme(false),
+		DarkScheme:  colors.colorScheme(true),
+	}
+
+	for _, token := range tokens.Spacing {
+		data.Spacing = append(data.Spacing, themeConstant{Name: tokenIdentifier(token.Name, "space"), Value: formatNumber(token.Value)})
+	}
+
+	for _, token := range tokens.Typography {
+		name := tokenIdentifier(token.Name, "text")
+		data.Typography = append(data.Typography, themeConstant{Name: name, Value: generateTextStyle(token)})
+		if textThemeRoles[name] {
+			data.TextRoles = append(data.TextRoles, name)
+		}
+	}
+
+	for _, name := range colors.customColors() {
+		data.CustomColors = append(data.CustomColors, themeCustomColor{
+			Name:  name,
+			Light: colors.lookup(name, false),
+			Dark:  colors.lookup(name, true),
+		})
+	}
+
+	return render("theme/theme.dart.tmpl", data)
+}
@@ -0,0 +1,81 @@
+package templates
+
+import (
+	"flart/internal/utils"
+	"fmt"
+	"strings"
+)
+
+// usecaseData is the context of the use case templates
+type usecaseData struct {
+	Context
+	// Repository names the repository the use case calls, without the Repository suffix
+	Repository Names
+	// Returns is the Dart type resolved by call()
+	Returns      string
+	ReturnsModel bool
+	UseFreezed   bool
+	Injectable   bool
+	// Result is the value returned by the stubbed repository in tests, nil for void
+	Result *utils.Field
+	// Samples and Imports provide the test values of params and results typed with project models and enums
+	Samples map[string]string
+	Imports []string
+}
+
+// GenerateUseCase creates a use case class with a call() method and its params class
+func GenerateUseCase(name, repoName, returns string, params []utils.Field, useFreezed bool, packageName string, returnsModel, injectable bool) (string, error) {
+	if returns == "" {
+		returns = "void"
+	}
+
+	return render("usecase/usecase.dart.tmpl", usecaseData{
+		Context:      newContext(name, params, packageName),
+		Repository:   NewNames(repoName),
+		Returns:      returns,
+		ReturnsModel: returnsModel,
+		UseFreezed:   useFreezed,
+		Injectable:   injectable,
+	})
+}
+
+// GenerateUseCaseTest creates a unit test for a use case, stubbing the repository method it calls
+func GenerateUseCaseTest(name, repoName, returns string, params []utils.Field, packageName string, samples map[string]string, imports []string) (string, error) {
+	data := usecaseData{
+		Context:    newContext(name, params, packageName),
+		Repository: NewNames(repoName),
+		Samples:    samples,
+		Imports:    imports,
+	}
+	if returns != "" && returns != "void" {
+		data.Result = &utils.Field{Name: "result", Type: returns}
+	}
+	return render("usecase/test.dart.tmpl", data)
+}
+
+// UseCaseRepositoryMethod returns the declaration and the implementation of the repository
+// method a use case calls, named after the use case and taking its params as named arguments
+func UseCaseRepositoryMethod(name, returns string, params []utils.Field) (declaration, implementation string) {
+	if returns == "" {
+		returns = "void"
+	}
+
+	method := utils.ToCamelCase(name)
+	var args []string
+	for _, f := range params {
+		if f.IsNullable() {
+			args = append(args, fmt.Sprintf("%s %s", f.Type, f.Name))
+		} else {
+			args = append(args, fmt.Sprintf("required %s %s", f.Type, f.Name))
+		}
+	}
+	var list string
+	if len(args) > 0 {
+		list = "{" + strings.Join(args, ", ") + "}"
+	}
+	signature := fmt.Sprintf("Future<%s> %s(%s)", returns, method, list)
+
+	declaration = fmt.Sprintf("  %s;", signature)
+	implementation = fmt.Sprintf("  @override\n  %s async {\n    // TODO: Implement %s\n    throw UnimplementedError();\n  }", signature, method)
+	return declaration, implementation
+}
@@ -0,0 +1,30 @@
+package templates
+
+import (
+	"flart/internal/utils"
+)
+
+// widgetData is the context of the widget templates
+type widgetData struct {
+	Context
+	Stateful bool
+	// Imports are the project models and enums the params or their test values need
+	Imports []string
+	// Samples maps project models and enums to the test values of params typed with them
+	Samples map[string]string
+}
+
+// GenerateWidget creates a reusable stateless or stateful widget with the given constructor params
+func GenerateWidget(name string, stateful bool, params []utils.Field, imports []string) (string, error) {
+	return render("widget/widget.dart.tmpl", widgetData{Context: newContext(name, params, ""), Stateful: stateful, Imports: imports})
+}
+
+// GenerateWidgetTest creates a widget test that pumps the widget inside a MaterialApp
+func GenerateWidgetTest(name string, params []utils.Field, packageName string, samples map[string]string, imports []string) (string, error) {
+	return render("widget/test.dart.tmpl", widgetData{Context: newContext(name, params, packageName), Samples: samples, Imports: imports})
+}
+
+// GenerateWidgetGoldenTest creates a golden test scaffold for the widget
+func GenerateWidgetGoldenTest(name string, params []utils.Field, packageName string, samples map[string]string, imports []string) (string, error) {
+	return render("widget/golden_test.dart.tmpl", widgetData{Context: newContext(name, params, packageName), Samples: samples, Imports: imports})
+}
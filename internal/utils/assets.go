@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AssetKind groups assets by the kind of constant generated for them
+type AssetKind string
+
+const (
+	AssetImage AssetKind = "image"
+	AssetFont  AssetKind = "font"
+	AssetJSON  AssetKind = "json"
+)
+
+// assetKinds maps supported file extensions to their asset kind
+var assetKinds = map[string]AssetKind{
+	".png":  AssetImage,
+	".jpg":  AssetImage,
+	".jpeg": AssetImage,
+	".gif":  AssetImage,
+	".webp": AssetImage,
+	".bmp":  AssetImage,
+	".svg":  AssetImage,
+	".ttf":  AssetFont,
+	".otf":  AssetFont,
+	".json": AssetJSON,
+}
+
+// resolutionDir matches Flutter's resolution-aware variant directories, e.g. 2.0x
+var resolutionDir = regexp.MustCompile(`^\d+(\.\d+)?x$`)
+
+// Asset is a single declared asset file
+type Asset struct {
+	Path string
+	Kind AssetKind
+}
+
+// FontFamily is a font family declared under flutter.fonts
+type FontFamily struct {
+	Family string
+	Assets []string
+}
+
+// PubspecAssets holds the asset and font declarations of pubspec.yaml
+type PubspecAssets struct {
+	Assets []string
+	Fonts  []FontFamily
+}
+
+type pubspecFlutter struct {
+	Flutter struct {
+		Assets []yaml.Node `yaml:"assets"`
+		Fonts  []struct {
+			Family string `yaml:"family"`
+			Fonts  []struct {
+				Asset string `yaml:"asset"`
+			} `yaml:"fonts"`
+		} `yaml:"fonts"`
+	} `yaml:"flutter"`
+}
+
+// GetPubspecAssets reads the flutter.assets and flutter.fonts entries of pubspec.yaml
+func GetPubspecAssets(projectDir string) (*PubspecAssets, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, "pubspec.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pubspec.yaml: %w", err)
+	}
+
+	var pubspec pubspecFlutter
+	if err := yaml.Unmarshal(content, &pubspec); err != nil {
+		return nil, fmt.Errorf("failed to parse pubspec.yaml: %w", err)
+	}
+
+	result := &PubspecAssets{}
+	for _, node := range pubspec.Flutter.Assets {
+		// Entries are either plain paths or maps with a path key
+		var entry string
+		if node.Kind == yaml.MappingNode {
+			var mapped struct {
+				Path string `yaml:"path"`
+			}
+			if err := node.Decode(&mapped); err != nil {
+				return nil, fmt.Errorf("failed to parse asset entry: %w", err)
+			}
+			entry = mapped.Path
+		} else if err := node.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("failed to parse asset entry: %w", err)
+		}
+		if entry != "" {
+			result.Assets = append(result.Assets, entry)
+		}
+	}
+
+	for _, font := range pubspec.Flutter.Fonts {
+		family := FontFamily{Family: font.Family}
+		for _, file := range font.Fonts {
+			family.Assets = append(family.Assets, file.Asset)
+		}
+		result.Fonts = append(result.Fonts, family)
+	}
+
+	return result, nil
+}
+
+// ScanAssets resolves declared asset entries into the supported files they cover
+func ScanAssets(projectDir string, declared *PubspecAssets) ([]Asset, error) {
+	seen := map[string]bool{}
+	var assets []Asset
+
+	add := func(assetPath string) {
+		kind, ok := assetKinds[strings.ToLower(path.Ext(assetPath))]
+		if !ok || seen[assetPath] {
+			return
+		}
+		seen[assetPath] = true
+		assets = append(assets, Asset{Path: assetPath, Kind: kind})
+	}
+
+	for _, entry := range declared.Assets {
+		if !strings.HasSuffix(entry, "/") {
+			add(entry)
+			continue
+		}
+
+		// Directory entries only cover the files directly inside them
+		files, err := os.ReadDir(filepath.Join(projectDir, filepath.FromSlash(entry)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read asset directory %s: %w", entry, err)
+		}
+		for _, file := range files {
+			if !file.IsDir() && !strings.HasPrefix(file.Name(), ".") {
+				add(entry + file.Name())
+			}
+		}
+	}
+
+	for _, family := range declared.Fonts {
+		for _, file := range family.Assets {
+			add(file)
+		}
+	}
+
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Path < assets[j].Path })
+	return assets, nil
+}
+
+// AssetRoots returns the sorted top-level directories holding declared assets and fonts
+func AssetRoots(declared *PubspecAssets) []string {
+	seen := map[string]bool{}
+	var roots []string
+	add := func(entry string) {
+		root := strings.SplitN(entry, "/", 2)[0]
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+
+	for _, entry := range declared.Assets {
+		add(entry)
+	}
+	for _, family := range declared.Fonts {
+		for _, file := range family.Assets {
+			add(file)
+		}
+	}
+
+	sort.Strings(roots)
+	return roots
+}
+
+// UndeclaredAssets returns files under the declared asset roots that pubspec.yaml does not cover
+func UndeclaredAssets(projectDir string, declared *PubspecAssets) ([]string, error) {
+	covered := map[string]bool{}
+	for _, entry := range declared.Assets {
+		covered[entry] = true
+	}
+	for _, family := range declared.Fonts {
+		for _, file := range family.Assets {
+			covered[file] = true
+		}
+	}
+
+	var undeclared []string
+	for _, root := range AssetRoots(declared) {
+		rootDir := filepath.Join(projectDir, root)
+		info, err := os.Stat(rootDir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+
+		err = filepath.WalkDir(rootDir, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+				return nil
+			}
+
+			rel, err := filepath.Rel(projectDir, file)
+			if err != nil {
+				return err
+			}
+			assetPath := filepath.ToSlash(rel)
+			dir := path.Dir(assetPath) + "/"
+
+			// Resolution variants are covered by their parent directory
+			if resolutionDir.MatchString(path.Base(path.Dir(assetPath))) {
+				dir = path.Dir(path.Dir(assetPath)) + "/"
+			}
+
+			if !covered[assetPath] && !covered[dir] {
+				undeclared = append(undeclared, assetPath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+
+	return undeclared, nil
+}
+
+// AssetIdentifier builds a camelCase Dart identifier from an asset path
+func AssetIdentifier(assetPath string) string {
+	trimmed := strings.TrimPrefix(assetPath, "assets/")
+	ext := path.Ext(trimmed)
+
+	// Treat every non-alphanumeric character as a word boundary
+	words := regexp.MustCompile(`[^A-Za-z0-9]+`).Split(strings.TrimSuffix(trimmed, ext), -1)
+	name := ToCamelCase(ToSnakeCase(strings.Join(words, "_")) + "_" + strings.TrimPrefix(ext, "."))
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "asset" + ToPascalCase(name)
+	}
+	return name
+}
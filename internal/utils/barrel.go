@@ -16,8 +16,9 @@ func UpdateBarrelFile(dir, name, barrelFileName string) error {
 	// Create barrel file if it doesn't exist
 	if !FileExists(barrelPath) {
 		// Scan directory for existing files
-		entries, err := os.ReadDir(dir)
-		if err != nil {
+		// A directory that does not exist yet has nothing else to export
+		entries, err := ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("failed to read directory: %w", err)
 		}
 
@@ -47,11 +48,11 @@ func UpdateBarrelFile(dir, name, barrelFileName string) error {
 
 		// Write barrel file with all exports
 		content := strings.Join(exports, "\n") + "\n"
-		return os.WriteFile(barrelPath, []byte(content), 0644)
+		return WriteFile(barrelPath, []byte(content))
 	}
 
 	// Read existing barrel file
-	content, err := os.ReadFile(barrelPath)
+	content, err := ReadFile(barrelPath)
 	if err != nil {
 		return fmt.Errorf("failed to read barrel file: %w", err)
 	}
@@ -62,13 +63,7 @@ func UpdateBarrelFile(dir, name, barrelFileName string) error {
 	}
 
 	// Append new export
-	f, err := os.OpenFile(barrelPath, os.O_APPEND|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open barrel file: %w", err)
-	}
-	defer f.Close()
-
-	if _, err := f.WriteString(exportLine + "\n"); err != nil {
+	if err := WriteFile(barrelPath, append(content, exportLine+"\n"...)); err != nil {
 		return fmt.Errorf("failed to update barrel file: %w", err)
 	}
 
@@ -83,8 +78,9 @@ func UpdateScreenBarrelFile(dir, name, barrelFileName string) error {
 	// Create barrel file if it doesn't exist
 	if !FileExists(barrelPath) {
 		// Scan directory for screen subdirectories
-		entries, err := os.ReadDir(dir)
-		if err != nil {
+		// A directory that does not exist yet has nothing else to export
+		entries, err := ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("failed to read directory: %w", err)
 		}
 
@@ -111,11 +107,11 @@ func UpdateScreenBarrelFile(dir, name, barrelFileName string) error {
 
 		// Write barrel file with all exports
 		content := strings.Join(exports, "\n") + "\n"
-		return os.WriteFile(barrelPath, []byte(content), 0644)
+		return WriteFile(barrelPath, []byte(content))
 	}
 
 	// Rest of the function remains the same
-	content, err := os.ReadFile(barrelPath)
+	content, err := ReadFile(barrelPath)
 	if err != nil {
 		return fmt.Errorf("failed to read barrel file: %w", err)
 	}
@@ -124,13 +120,7 @@ func UpdateScreenBarrelFile(dir, name, barrelFileName string) error {
 		return nil
 	}
 
-	f, err := os.OpenFile(barrelPath, os.O_APPEND|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open barrel file: %w", err)
-	}
-	defer f.Close()
-
-	if _, err := f.WriteString(exportLine + "\n"); err != nil {
+	if err := WriteFile(barrelPath, append(content, exportLine+"\n"...)); err != nil {
 		return fmt.Errorf("failed to update barrel file: %w", err)
 	}
 
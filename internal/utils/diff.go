@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// diffLine is a line of an edit script, with kind ' ', '-' or '+'
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns the changes from oldText to newText in unified diff format, empty when they are equal
+func UnifiedDiff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	script := diffLines(splitLines(oldText), splitLines(newText))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	// Positions of the first line of each edit script entry in the old and new text
+	oldLine, newLine := make([]int, len(script)+1), make([]int, len(script)+1)
+	oldLine[0], newLine[0] = 1, 1
+	for i, line := range script {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if line.kind != '+' {
+			oldLine[i+1]++
+		}
+		if line.kind != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	for start := 0; start < len(script); {
+		// Find the next change and extend the hunk while changes are close together
+		first := start
+		for first < len(script) && script[first].kind == ' ' {
+			first++
+		}
+		if first == len(script) {
+			break
+		}
+
+		last := first
+		for i := first; i < len(script); i++ {
+			if script[i].kind != ' ' {
+				last = i
+			} else if i-last > 2*diffContext {
+				break
+			}
+		}
+
+		from := max(first-diffContext, start)
+		to := min(last+diffContext+1, len(script))
+
+		oldCount, newCount := 0, 0
+		for _, line := range script[from:to] {
+			if line.kind != '+' {
+				oldCount++
+			}
+			if line.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldLine[from], oldCount), hunkRange(newLine[from], newCount))
+		for _, line := range script[from:to] {
+			b.WriteByte(line.kind)
+			b.WriteString(line.text)
+			b.WriteByte('\n')
+		}
+
+		start = to
+	}
+
+	return b.String()
+}
+
+// hunkRange formats the start and length of a hunk side, where empty sides start before line 1
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines splits text into lines without their line endings
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes an edit script turning a into b from their longest common subsequence
+func diffLines(a, b []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var script []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			script = append(script, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			script = append(script, diffLine{'-', a[i]})
+			i++
+		default:
+			script = append(script, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		script = append(script, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		script = append(script, diffLine{'+', b[j]})
+	}
+	return script
+}
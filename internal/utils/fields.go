@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field describes a single name:Type pair passed on the command line.
+// Form fields may append validators, e.g. "password:String@min(8)".
+type Field struct {
+	Name       string
+	Type       string
+	Validators []string
+}
+
+// ParseFields parses a comma separated list of name:Type specs, e.g. "id:String,age:int?"
+func ParseFields(spec string) ([]Field, error) {
+	var fields []Field
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, fieldType, ok := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		fieldType = strings.TrimSpace(fieldType)
+		if !ok || name == "" || fieldType == "" {
+			return nil, fmt.Errorf("invalid field spec %q, expected name:Type", part)
+		}
+
+		// Split off validators, e.g. String@email@min(8)
+		validators := strings.Split(fieldType, "@")
+		fieldType = strings.TrimSpace(validators[0])
+		if fieldType == "" {
+			return nil, fmt.Errorf("invalid field spec %q, expected name:Type", part)
+		}
+
+		field := Field{Name: ToCamelCase(name), Type: fieldType}
+		for _, validator := range validators[1:] {
+			if validator = strings.TrimSpace(validator); validator != "" {
+				field.Validators = append(field.Validators, validator)
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// IsNullable reports whether the field type is declared nullable
+func (f Field) IsNullable() bool {
+	return strings.HasSuffix(f.Type, "?")
+}
+
+// SampleValue returns a Dart literal for the field type, used in generated tests
+func (f Field) SampleValue() string {
+	switch strings.TrimSuffix(f.Type, "?") {
+	case "String":
+		return fmt.Sprintf("'%s'", f.Name)
+	case "int", "num":
+		return "1"
+	case "double":
+		return "1.0"
+	case "bool":
+		return "true"
+	case "DateTime":
+		return "DateTime(2024)"
+	case "VoidCallback":
+		return "() {}"
+	case "Widget":
+		return "const SizedBox()"
+	default:
+		if strings.HasPrefix(f.Type, "List") {
+			return "const []"
+		}
+		if strings.HasPrefix(f.Type, "Map") {
+			return "const {}"
+		}
+		return "null"
+	}
+}
@@ -3,6 +3,11 @@ package utils
 import "os"
 
 func FileExists(filename string) bool {
+	// Staged files exist for the generation steps that follow
+	if content, ok := stagedContent(filename); ok {
+		return content != nil
+	}
+
 	_, err := os.Stat(filename)
 	return err == nil
 }
@@ -0,0 +1,576 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// changeSet tracks the files written during a dry run or a transaction.
+// Writes are staged in memory until the transaction is applied, and a dry run never applies them.
+type changeSet struct {
+	dryRun  bool
+	applied bool
+	// original holds the content of each touched file before the change set, nil when it did not exist
+	original map[string]*string
+	// staged holds the content to write, nil for files to remove
+	staged map[string]*string
+	order  []string
+	// createdDirs are the directories created when applying, removed again on rollback
+	createdDirs []string
+	commands    []string
+}
+
+// changes is set during a dry run or a transaction
+var changes *changeSet
+
+func newChangeSet(dryRun bool) *changeSet {
+	return &changeSet{dryRun: dryRun, original: map[string]*string{}, staged: map[string]*string{}}
+}
+
+// BeginDryRun makes WriteFile, MkdirAll and RunCommand record their effects instead of performing them
+func BeginDryRun() {
+	changes = newChangeSet(true)
+}
+
+// DryRun reports whether a dry run is in progress
+func DryRun() bool {
+	return changes != nil && changes.dryRun
+}
+
+// EndDryRun prints the planned changes as unified diffs followed by the planned commands
+func EndDryRun(w io.Writer) {
+	plan := changes
+	changes = nil
+	if plan == nil || !plan.dryRun {
+		return
+	}
+
+	changed := 0
+	for _, path := range plan.order {
+		name := displayPath(path)
+		original := plan.original[path]
+		planned := plan.staged[path]
+
+		switch {
+		case original == nil && planned == nil:
+			continue
+		case original == nil:
+			fmt.Fprintf(w, "Would create %s\n", name)
+			fmt.Fprint(w, UnifiedDiff("/dev/null", name, "", *planned))
+		case planned == nil:
+			fmt.Fprintf(w, "Would delete %s\n", name)
+			fmt.Fprint(w, UnifiedDiff(name, "/dev/null", *original, ""))
+		case *original != *planned:
+			fmt.Fprintf(w, "Would modify %s\n", name)
+			fmt.Fprint(w, UnifiedDiff(name, name, *original, *planned))
+		default:
+			continue
+		}
+		changed++
+	}
+
+	for _, command := range plan.commands {
+		fmt.Fprintf(w, "Would run: %s\n", command)
+	}
+
+	if changed == 0 && len(plan.commands) == 0 {
+		fmt.Fprintln(w, "Nothing would change")
+	}
+}
+
+// BeginTransaction stages the following writes until ApplyTransaction.
+// It returns false when a dry run or another transaction already tracks the writes.
+func BeginTransaction() bool {
+	if changes != nil {
+		return false
+	}
+	changes = newChangeSet(false)
+	return true
+}
+
+// Savepoint marks the staged state so that a failed step can be undone with RollbackToSavepoint
+type Savepoint struct {
+	staged map[string]*string
+	order  int
+	// dependencies is the number of pub add arguments deferred at the savepoint
+	dependencies int
+}
+
+// NewSavepoint returns the current staged state, or nil outside of a dry run or transaction
+func NewSavepoint() *Savepoint {
+	if changes == nil || changes.applied {
+		return nil
+	}
+
+	staged := make(map[string]*string, len(changes.staged))
+	for path, content := range changes.staged {
+		staged[path] = content
+	}
+	return &Savepoint{staged: staged, order: len(changes.order), dependencies: len(dependencyBatch)}
+}
+
+// RollbackToSavepoint drops the writes staged and the dependencies deferred since the savepoint
+func RollbackToSavepoint(s *Savepoint) {
+	if s == nil || changes == nil || changes.applied {
+		return
+	}
+
+	if len(dependencyBatch) > s.dependencies {
+		dependencyBatch = dependencyBatch[:s.dependencies]
+	}
+
+	for _, path := range changes.order[s.order:] {
+		delete(changes.original, path)
+	}
+	changes.order = changes.order[:s.order]
+	changes.staged = s.staged
+}
+
+// ApplyTransaction writes every staged file. Files written afterwards are tracked until EndTransaction.
+func ApplyTransaction() error {
+	if changes == nil || changes.dryRun || changes.applied {
+		return nil
+	}
+	changes.applied = true
+
+	for _, path := range changes.order {
+		content, ok := changes.staged[path]
+		if !ok {
+			continue
+		}
+		if content == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove file %s: %w", path, err)
+			}
+			continue
+		}
+
+		if err := changes.mkdirAll(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(*content), 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", path, err)
+		}
+	}
+
+	// Track the part files that build_runner generates for the new sources
+	for _, path := range append([]string{}, changes.order...) {
+		content := changes.staged[path]
+		if content == nil {
+			continue
+		}
+		for _, part := range partFiles(path, *content) {
+			if err := Track(part); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// partPattern matches part directives such as part 'user.g.dart';
+var partPattern = regexp.MustCompile(`(?m)^part '([^']+)';`)
+
+// partFiles returns the files named by the part directives of a Dart source
+func partFiles(path, content string) []string {
+	if !strings.HasSuffix(path, ".dart") {
+		return nil
+	}
+
+	var parts []string
+	for _, match := range partPattern.FindAllStringSubmatch(content, -1) {
+		parts = append(parts, filepath.Join(filepath.Dir(path), filepath.FromSlash(match[1])))
+	}
+	return parts
+}
+
+// mkdirAll creates dir and records the directories that did not exist
+func (c *changeSet) mkdirAll(dir string) error {
+	var missing []string
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := os.Stat(current); err == nil {
+			break
+		}
+		missing = append(missing, current)
+		if parent := filepath.Dir(current); parent == current {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	// Deepest directories last, so that rollback removes them first
+	for i := len(missing) - 1; i >= 0; i-- {
+		c.createdDirs = append(c.createdDirs, missing[i])
+	}
+	return nil
+}
+
+// Track records the current content of a file about to be changed by an external command,
+// so that a rollback restores it
+func Track(path string) error {
+	if changes == nil || changes.dryRun {
+		return nil
+	}
+
+	path = absPath(path)
+	if _, ok := changes.original[path]; ok {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err == nil {
+		original := string(content)
+		changes.original[path] = &original
+	} else {
+		changes.original[path] = nil
+	}
+	changes.order = append(changes.order, path)
+	return nil
+}
+
+// RollbackTransaction restores every file changed by the transaction and ends it.
+// It returns a line per removed or restored file.
+func RollbackTransaction() ([]string, error) {
+	current := changes
+	changes = nil
+	if current == nil || current.dryRun || !current.applied {
+		return nil, nil
+	}
+
+	var report []string
+	var errs []string
+	for i := len(current.order) - 1; i >= 0; i-- {
+		path := current.order[i]
+		original := current.original[path]
+
+		if original == nil {
+			if err := os.Remove(path); err == nil {
+				report = append(report, "removed "+displayPath(path))
+			} else if !os.IsNotExist(err) {
+				errs = append(errs, err.Error())
+			}
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err == nil && string(content) == *original {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(*original), 0644); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		report = append(report, "restored "+displayPath(path))
+	}
+
+	for i := len(current.createdDirs) - 1; i >= 0; i-- {
+		// Only empty directories are removed, leaving anything written by other tools
+		os.Remove(current.createdDirs[i])
+	}
+
+	sort.Strings(report)
+	if len(errs) > 0 {
+		return report, fmt.Errorf("failed to roll back: %s", strings.Join(errs, "; "))
+	}
+	return report, nil
+}
+
+// FileChange is a file changed by a transaction, with nil contents for a missing file
+type FileChange struct {
+	Path   string
+	Before *string
+	After  *string
+}
+
+// Changes describes what an applied transaction changed on disk
+type Changes struct {
+	Files []FileChange
+	// Dirs are the directories the transaction created, parents first
+	Dirs []string
+}
+
+// EndTransaction stops tracking writes, keeping the applied changes and discarding unapplied ones.
+// It returns the files whose content differs from before the transaction, nil if none were applied.
+func EndTransaction() *Changes {
+	current := changes
+	if current == nil || current.dryRun {
+		return nil
+	}
+	changes = nil
+	if !current.applied {
+		return nil
+	}
+
+	result := &Changes{Dirs: current.createdDirs}
+	for _, path := range current.order {
+		before := current.original[path]
+		var after *string
+		if content, err := os.ReadFile(path); err == nil {
+			text := string(content)
+			after = &text
+		}
+
+		if (before == nil && after == nil) || (before != nil && after != nil && *before == *after) {
+			continue
+		}
+		result.Files = append(result.Files, FileChange{Path: path, Before: before, After: after})
+	}
+	return result
+}
+
+// displayPath shortens path relative to the working directory when it is below it
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
+// stagedContent returns the staged content of path, nil when it is staged for removal
+func stagedContent(path string) (*string, bool) {
+	if changes == nil || changes.applied {
+		return nil, false
+	}
+	content, ok := changes.staged[absPath(path)]
+	return content, ok
+}
+
+// ReadFile reads a file, returning the staged content during a dry run or transaction
+func ReadFile(path string) ([]byte, error) {
+	if content, ok := stagedContent(path); ok {
+		if content == nil {
+			return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+		}
+		return []byte(*content), nil
+	}
+	return os.ReadFile(path)
+}
+
+// WriteFile writes a generated file, staging it during a dry run or a transaction
+func WriteFile(path string, data []byte) error {
+	if changes == nil {
+		return os.WriteFile(path, data, 0644)
+	}
+
+	if changes.applied {
+		if err := Track(path); err != nil {
+			return err
+		}
+		return os.WriteFile(path, data, 0644)
+	}
+
+	path = absPath(path)
+	if err := changes.stage(path); err != nil {
+		return err
+	}
+	content := string(data)
+	changes.staged[path] = &content
+	return nil
+}
+
+// RemoveFile removes a file, staging the removal during a dry run or a transaction
+func RemoveFile(path string) error {
+	if changes == nil {
+		return os.Remove(path)
+	}
+
+	if changes.applied {
+		if err := Track(path); err != nil {
+			return err
+		}
+		return os.Remove(path)
+	}
+
+	path = absPath(path)
+	if err := changes.stage(path); err != nil {
+		return err
+	}
+	changes.staged[path] = nil
+	return nil
+}
+
+// stage records the original content of path the first time it is staged
+func (c *changeSet) stage(path string) error {
+	if _, ok := c.staged[path]; ok {
+		return nil
+	}
+
+	if content, err := os.ReadFile(path); err == nil {
+		original := string(content)
+		c.original[path] = &original
+	} else if os.IsNotExist(err) {
+		c.original[path] = nil
+	} else {
+		return err
+	}
+	c.order = append(c.order, path)
+	return nil
+}
+
+// stagedEntry is a directory entry for a file or directory that only exists in the staged writes
+type stagedEntry struct {
+	name string
+	dir  bool
+	size int64
+}
+
+func (e stagedEntry) Name() string               { return e.name }
+func (e stagedEntry) IsDir() bool                { return e.dir }
+func (e stagedEntry) Type() fs.FileMode          { return e.Mode().Type() }
+func (e stagedEntry) Info() (fs.FileInfo, error) { return e, nil }
+func (e stagedEntry) Size() int64                { return e.size }
+func (e stagedEntry) ModTime() time.Time         { return time.Time{} }
+func (e stagedEntry) Sys() any                   { return nil }
+
+func (e stagedEntry) Mode() fs.FileMode {
+	if e.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+// ReadDir lists a directory sorted by name. During a dry run or a transaction, the files staged
+// below it are listed and the ones staged for removal are not, so a directory that will only be
+// created when the writes are applied is listed as well.
+func ReadDir(dir string) ([]fs.DirEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if changes == nil || changes.applied {
+		return entries, err
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	found := err == nil
+	byName := map[string]fs.DirEntry{}
+	for _, entry := range entries {
+		byName[entry.Name()] = entry
+	}
+
+	dir = absPath(dir)
+	for path, content := range changes.staged {
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+
+		name, _, nested := strings.Cut(rel, string(filepath.Separator))
+		switch {
+		case content == nil && !nested:
+			delete(byName, name)
+		case content == nil:
+		case nested:
+			found = true
+			if _, ok := byName[name]; !ok {
+				byName[name] = stagedEntry{name: name, dir: true}
+			}
+		default:
+			found = true
+			byName[name] = stagedEntry{name: name, size: int64(len(*content))}
+		}
+	}
+	if !found {
+		return nil, err
+	}
+
+	merged := make([]fs.DirEntry, 0, len(byName))
+	for _, entry := range byName {
+		merged = append(merged, entry)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Name() < merged[j].Name() })
+	return merged, nil
+}
+
+// MkdirAll creates a directory and its parents. Staged files get their directories when applied.
+func MkdirAll(dir string) error {
+	if changes != nil && !changes.applied {
+		return nil
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+// RunCommand runs an external command in dir with its output shown, or records it during a dry run
+func RunCommand(dir, name string, args ...string) error {
+	return runCommand(dir, true, name, args...)
+}
+
+// RunQuietCommand runs an external command in dir with its output discarded, or records it during a dry run
+func RunQuietCommand(dir, name string, args ...string) error {
+	return runCommand(dir, false, name, args...)
+}
+
+func runCommand(dir string, showOutput bool, name string, args ...string) error {
+	if DryRun() {
+		words := []string{name}
+		for _, arg := range args {
+			if filepath.IsAbs(arg) {
+				arg = displayPath(arg)
+			}
+			words = append(words, arg)
+		}
+		command := strings.Join(words, " ")
+		changes.commands = append(changes.commands, fmt.Sprintf("%s (in %s)", command, displayPath(absPath(dir))))
+		return nil
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if showOutput {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+	return cmd.Run()
+}
+
+// FormatDart returns content as dart format writes it. A temporary file is formatted,
+// so that the project is left alone, even during a dry run.
+func FormatDart(projectDir, content string) (string, error) {
+	dir, err := os.MkdirTemp("", "flart-format")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "generated.dart")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write file %s: %w", file, err)
+	}
+
+	cmd := exec.Command("dart", "format", file)
+	cmd.Dir = projectDir
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	formatted, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", file, err)
+	}
+	return string(formatted), nil
+}
+
+// absPath makes path absolute so that files are tracked once whatever the spelling
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// importPattern matches the URI of import, export, part and part of directives
+var importPattern = regexp.MustCompile(`(?m)^(\s*(?:import|export|part(?:\s+of)?)\s+')([^']+)'`)
+
+// ProjectDartFiles returns the Dart files under lib/ and test/, generated files included
+func ProjectDartFiles(projectDir string) ([]string, error) {
+	var files []string
+	for _, dir := range []string{"lib", "test"} {
+		root := filepath.Join(projectDir, dir)
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if !entry.IsDir() && strings.HasSuffix(path, ".dart") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ResolveImport returns the file an import URI of fromFile refers to, or "" for other packages and dart: libraries
+func ResolveImport(projectDir, packageName, fromFile, uri string) string {
+	if rest, ok := strings.CutPrefix(uri, "package:"+packageName+"/"); ok {
+		return filepath.Join(projectDir, "lib", filepath.FromSlash(rest))
+	}
+	if strings.Contains(uri, ":") {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(fromFile), filepath.FromSlash(uri))
+}
+
+// FindImporters returns the project files that import, export or include any of targets, other than targets themselves
+func FindImporters(projectDir, packageName string, targets []string) ([]string, error) {
+	wanted := map[string]bool{}
+	for _, target := range targets {
+		wanted[absPath(target)] = true
+	}
+
+	files, err := ProjectDartFiles(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var importers []string
+	for _, file := range files {
+		if wanted[absPath(file)] {
+			continue
+		}
+		content, err := ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range importPattern.FindAllStringSubmatch(string(content), -1) {
+			if resolved := ResolveImport(projectDir, packageName, file, match[2]); resolved != "" && wanted[absPath(resolved)] {
+				importers = append(importers, file)
+				break
+			}
+		}
+	}
+	return importers, nil
+}
+
+// RewriteImports replaces the URI of every import, export and part directive of a Dart source with rewrite(uri)
+func RewriteImports(content string, rewrite func(uri string) string) string {
+	return importPattern.ReplaceAllStringFunc(content, func(directive string) string {
+		match := importPattern.FindStringSubmatch(directive)
+		return match[1] + rewrite(match[2]) + "'"
+	})
+}
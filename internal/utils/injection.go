@@ -0,0 +1,276 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// InjectionMarker marks where generated registrations are inserted in the injection file
+const InjectionMarker = "// flart:registrations"
+
+// RoutesMarker marks where generated routes are inserted in the router file
+const RoutesMarker = "// flart:routes"
+
+// AddRegistration adds an import and a service locator registration of typeName to the injection
+// file, unless the type is already registered
+func AddRegistration(injectionFile, importLine, typeName, registration string) error {
+	registered, err := HasRegistration(injectionFile, typeName)
+	if err != nil || registered {
+		return err
+	}
+	return InsertAtMarker(injectionFile, InjectionMarker, importLine, registration)
+}
+
+// HasRegistration reports whether the injection file registers typeName, e.g. with
+// sl.registerLazySingleton<UserRepository>(...). Registrations wrapped by dart format are found too.
+func HasRegistration(injectionFile, typeName string) (bool, error) {
+	content, err := ReadFile(injectionFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", injectionFile, err)
+	}
+	pattern := regexp.MustCompile(`\.register\w*\s*<\s*` + regexp.QuoteMeta(typeName) + `\s*>\s*\(`)
+	return pattern.Match(content), nil
+}
+
+// InsertAtMarker adds an import and inserts line above the marker comment in file
+func InsertAtMarker(file, marker, importLine, line string) error {
+	content, err := ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	text := string(content)
+	if strings.Contains(text, line) {
+		return nil
+	}
+
+	lines := strings.Split(text, "\n")
+	var result []string
+	lastImport := -1
+	markerFound := false
+
+	for _, current := range lines {
+		trimmed := strings.TrimSpace(current)
+
+		// Insert above the marker, keeping its indentation
+		if trimmed == marker && !markerFound {
+			indent := current[:len(current)-len(strings.TrimLeft(current, " \t"))]
+			result = append(result, indent+line)
+			markerFound = true
+		}
+
+		result = append(result, current)
+		if strings.HasPrefix(trimmed, "import ") {
+			lastImport = len(result) - 1
+		}
+	}
+
+	if !markerFound {
+		return fmt.Errorf("marker %q not found in %s", marker, file)
+	}
+
+	// Add import after the last existing import
+	if importLine != "" && !strings.Contains(text, importLine) {
+		result = append(result[:lastImport+1], append([]string{importLine}, result[lastImport+1:]...)...)
+	}
+
+	return WriteFile(file, []byte(strings.Join(result, "\n")))
+}
+
+// AddClassMember appends member, indented as a class member, to the body of the named class in
+// file and adds the missing imports. A class with an empty body on one line, e.g. "class A {}",
+// is expanded.
+func AddClassMember(file, className, member string, imports []string) error {
+	content, err := ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	text := string(content)
+
+	declaration := regexp.MustCompile(`(?m)^(?:abstract\s+)?class\s+` + regexp.QuoteMeta(className) + `\b[^{;]*\{`)
+	loc := declaration.FindStringIndex(text)
+	if loc == nil {
+		return fmt.Errorf("class %s not found in %s", className, file)
+	}
+
+	// Find the brace closing the class body
+	end, depth := -1, 1
+	for i := loc[1]; i < len(text) && end < 0; i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return fmt.Errorf("class %s is not closed in %s", className, file)
+	}
+
+	body := strings.TrimRight(text[loc[1]:end], " \t\n")
+	if strings.TrimSpace(body) != "" {
+		body += "\n"
+	}
+	text = text[:loc[1]] + body + "\n" + member + "\n" + text[end:]
+
+	// Add imports after the last existing import
+	lines := strings.Split(text, "\n")
+	lastImport := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "import ") {
+			lastImport = i
+		}
+	}
+	var missing []string
+	for _, importLine := range imports {
+		if !strings.Contains(text, importLine) {
+			missing = append(missing, importLine)
+		}
+	}
+	if len(missing) > 0 && lastImport < 0 {
+		// Separate the first imports from the declarations
+		missing = append(missing, "")
+	}
+	lines = append(lines[:lastImport+1], append(missing, lines[lastImport+1:]...)...)
+
+	return WriteFile(file, []byte(strings.Join(lines, "\n")))
+}
+
+// RemoveLines removes the lines of file for which match returns true, given the trimmed line.
+// A missing file is left alone.
+func RemoveLines(file string, match func(line string) bool) error {
+	content, err := ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	result := lines[:0:0]
+	for _, current := range lines {
+		if !match(strings.TrimSpace(current)) {
+			result = append(result, current)
+		}
+	}
+	if len(result) == len(lines) {
+		return nil
+	}
+
+	return WriteFile(file, []byte(strings.Join(result, "\n")))
+}
+
+// RemoveStatements removes from file each statement or list element that starts on a line
+// containing one of snippets, including registrations wrapped over several lines by dart format.
+// A statement ends with the first ; or , outside of brackets, strings and comments. Comment
+// lines and a missing file are left alone.
+func RemoveStatements(file string, snippets ...string) error {
+	content, err := ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	text := string(content)
+	var result strings.Builder
+	removed := false
+	for pos := 0; pos < len(text); {
+		lineEnd := len(text)
+		if i := strings.IndexByte(text[pos:], '\n'); i >= 0 {
+			lineEnd = pos + i + 1
+		}
+		line := text[pos:lineEnd]
+		if strings.HasPrefix(strings.TrimSpace(line), "//") || !containsAny(line, snippets) {
+			result.WriteString(line)
+			pos = lineEnd
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		end := statementEnd(text, pos+indent)
+		if rest := strings.IndexByte(text[end:], '\n'); rest >= 0 && strings.TrimSpace(text[end:end+rest]) == "" {
+			end += rest + 1
+		} else {
+			// Keep the indentation for whatever follows the statement on its last line
+			result.WriteString(line[:indent])
+		}
+		removed = true
+		pos = end
+	}
+	if !removed {
+		return nil
+	}
+
+	return WriteFile(file, []byte(result.String()))
+}
+
+// containsAny reports whether s contains one of substrings
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// statementEnd returns the end of the Dart statement or list element starting at start: after
+// its ; or , or before the bracket closing the enclosing list
+func statementEnd(text string, start int) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		case ';', ',':
+			if depth == 0 {
+				return i + 1
+			}
+		case '\'', '"':
+			// Skip the string, honouring escaped quotes
+			for i++; i < len(text) && text[i] != c; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+		case '/':
+			if strings.HasPrefix(text[i:], "//") {
+				end := strings.IndexByte(text[i:], '\n')
+				if end < 0 {
+					return len(text)
+				}
+				i += end
+			} else if strings.HasPrefix(text[i:], "/*") {
+				end := strings.Index(text[i+2:], "*/")
+				if end < 0 {
+					return len(text)
+				}
+				i += end + 3
+			}
+		}
+	}
+	return len(text)
+}
+
+// PackageImport converts a file under lib/ into its package: import line
+func PackageImport(projectDir, packageName, file string) (string, error) {
+	rel, err := filepath.Rel(filepath.Join(projectDir, "lib"), file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is not inside the lib directory", file)
+	}
+
+	return fmt.Sprintf("import 'package:%s/%s';", packageName, filepath.ToSlash(rel)), nil
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ArbEntry is a single localized string in an ARB file
+type ArbEntry struct {
+	Key   string
+	Value string
+}
+
+// ArbFiles returns the ARB files found in dir, sorted by name
+func ArbFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.arb"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ARB files: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no ARB files found in %s", dir)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readArbKeys returns the message keys of an ARB file, ignoring @ metadata
+func readArbKeys(file string) (map[string]bool, error) {
+	content, err := ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	keys := map[string]bool{}
+	for key := range data {
+		if !strings.HasPrefix(key, "@") {
+			keys[key] = true
+		}
+	}
+	return keys, nil
+}
+
+// AddArbEntries appends entries missing from every ARB file in dir, keeping existing formatting
+func AddArbEntries(dir string, entries []ArbEntry) error {
+	files, err := ArbFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		keys, err := readArbKeys(file)
+		if err != nil {
+			return err
+		}
+
+		var lines []string
+		for _, entry := range entries {
+			if keys[entry.Key] {
+				continue
+			}
+			value, err := json.Marshal(entry.Value)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", entry.Key, err)
+			}
+			lines = append(lines, fmt.Sprintf("  %q: %s", entry.Key, value))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		content, err := ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		// Insert before the closing brace of the top-level object
+		text := strings.TrimRight(string(content), " \t\r\n")
+		end := strings.LastIndex(text, "}")
+		if end == -1 {
+			return fmt.Errorf("invalid ARB file %s", file)
+		}
+		body := strings.TrimRight(text[:end], " \t\r\n")
+		separator := ",\n"
+		if strings.HasSuffix(body, "{") {
+			separator = "\n"
+		}
+		updated := body + separator + strings.Join(lines, ",\n") + "\n}\n"
+
+		if !json.Valid([]byte(updated)) {
+			return fmt.Errorf("failed to update %s: result is not valid JSON", file)
+		}
+		if err := WriteFile(file, []byte(updated)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// MissingArbKeys returns, per ARB file in dir, the keys defined in other ARB files but not in it
+func MissingArbKeys(dir string) (map[string][]string, error) {
+	files, err := ArbFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	all := map[string]bool{}
+	perFile := map[string]map[string]bool{}
+	for _, file := range files {
+		keys, err := readArbKeys(file)
+		if err != nil {
+			return nil, err
+		}
+		perFile[file] = keys
+		for key := range keys {
+			all[key] = true
+		}
+	}
+
+	missing := map[string][]string{}
+	for _, file := range files {
+		for key := range all {
+			if !perFile[file][key] {
+				missing[file] = append(missing[file], key)
+			}
+		}
+		sort.Strings(missing[file])
+	}
+	for file, keys := range missing {
+		if len(keys) == 0 {
+			delete(missing, file)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,101 @@
+package utils
+
+import "strings"
+
+// Conflict markers written around the lines both sides changed differently
+const (
+	conflictStart     = "<<<<<<< current"
+	conflictSeparator = "======="
+	conflictEnd       = ">>>>>>> generated"
+)
+
+// MergeLines merges the changes from base to current and from base to generated, line by line.
+// Lines changed differently on both sides are kept between conflict markers, and the second
+// result reports whether there were any.
+func MergeLines(base, current, generated string) (string, bool) {
+	baseLines := splitLines(base)
+	currentLines := splitLines(current)
+	generatedLines := splitLines(generated)
+
+	toCurrent := matchLines(baseLines, currentLines)
+	toGenerated := matchLines(baseLines, generatedLines)
+
+	var merged []string
+	conflicted := false
+	resolve := func(baseChunk, currentChunk, generatedChunk []string) {
+		switch {
+		case equalLines(currentChunk, baseChunk):
+			merged = append(merged, generatedChunk...)
+		case equalLines(generatedChunk, baseChunk), equalLines(currentChunk, generatedChunk):
+			merged = append(merged, currentChunk...)
+		default:
+			conflicted = true
+			merged = append(merged, conflictStart)
+			merged = append(merged, currentChunk...)
+			merged = append(merged, conflictSeparator)
+			merged = append(merged, generatedChunk...)
+			merged = append(merged, conflictEnd)
+		}
+	}
+
+	o, c, g := 0, 0, 0
+	for {
+		// The next base line kept by both sides ends the current chunk
+		next := o
+		for next < len(baseLines) && (toCurrent[next] < 0 || toGenerated[next] < 0) {
+			next++
+		}
+		if next == len(baseLines) {
+			resolve(baseLines[o:], currentLines[c:], generatedLines[g:])
+			break
+		}
+
+		if next == o && toCurrent[o] == c && toGenerated[o] == g {
+			merged = append(merged, baseLines[o])
+			o, c, g = o+1, c+1, g+1
+			continue
+		}
+
+		resolve(baseLines[o:next], currentLines[c:toCurrent[next]], generatedLines[g:toGenerated[next]])
+		o, c, g = next, toCurrent[next], toGenerated[next]
+	}
+
+	result := strings.Join(merged, "\n")
+	if len(merged) > 0 && (strings.HasSuffix(generated, "\n") || strings.HasSuffix(current, "\n")) {
+		result += "\n"
+	}
+	return result, conflicted
+}
+
+// matchLines returns for each line of a the index of the same line in b, or -1 when it was removed
+func matchLines(a, b []string) []int {
+	matches := make([]int, len(a))
+	i, j := 0, 0
+	for _, line := range diffLines(a, b) {
+		switch line.kind {
+		case ' ':
+			matches[i] = j
+			i++
+			j++
+		case '-':
+			matches[i] = -1
+			i++
+		case '+':
+			j++
+		}
+	}
+	return matches
+}
+
+// equalLines reports whether two chunks hold the same lines
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
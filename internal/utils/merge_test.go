@@ -0,0 +1,27 @@
+package utils
+
+import "testing"
+
+func TestMergeLines(t *testing.T) {
+	base := "a\nb\nc\n"
+	tests := []struct {
+		name       string
+		current    string
+		generated  string
+		want       string
+		conflicted bool
+	}{
+		{"unchanged", base, base, base, false},
+		{"edited by the user", "a\nB\nc\n", base, "a\nB\nc\n", false},
+		{"generated differently", base, "a\nb\nc\nd\n", "a\nb\nc\nd\n", false},
+		{"both sides", "a0\na\nb\nc\n", "a\nb\nc\nd\n", "a0\na\nb\nc\nd\n", false},
+		{"same change", "a\nB\nc\n", "a\nB\nc\n", "a\nB\nc\n", false},
+		{"conflict", "a\nB\nc\n", "a\nX\nc\n", "a\n<<<<<<< current\nB\n=======\nX\n>>>>>>> generated\nc\n", true},
+	}
+	for _, tt := range tests {
+		got, conflicted := MergeLines(base, tt.current, tt.generated)
+		if got != tt.want || conflicted != tt.conflicted {
+			t.Errorf("%s: MergeLines() = %q, %v, want %q, %v", tt.name, got, conflicted, tt.want, tt.conflicted)
+		}
+	}
+}
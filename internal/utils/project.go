@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// BuiltinFieldTypes are the Dart types suggested for name:Type specs in every project
+var BuiltinFieldTypes = []string{"String", "int", "double", "num", "bool", "DateTime"}
+
+// fieldDeclarationPattern matches final fields such as "final List<Order>? orders;"
+var fieldDeclarationPattern = regexp.MustCompile(`(?m)^\s*final\s+([A-Za-z_][\w<>?, ]*?)\s+(\w+);`)
+
+// freezedParameterPattern matches a Freezed factory parameter such as "required List<Order>? orders,"
+var freezedParameterPattern = regexp.MustCompile(`^(?:@\w+(?:\([^)]*\))?\s+)*(?:required\s+)?([A-Za-z_][\w<>?, ]*?)\s+(\w+),?$`)
+
+// ProjectModel is a model or enum declared in lib/models
+type ProjectModel struct {
+	Name string
+	Enum bool
+	// Fields are the fields of a model, read from its Freezed factory or its final fields
+	Fields []Field
+}
+
+// dartFileNames returns the sorted names of the hand written Dart files in dir, without extension.
+// Barrel files named after the directory and generated files are skipped.
+func dartFileNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".dart") ||
+			strings.HasSuffix(name, ".g.dart") || strings.HasSuffix(name, ".freezed.dart") {
+			continue
+		}
+		name = strings.TrimSuffix(name, ".dart")
+		if name == filepath.Base(dir) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ProjectModels returns the models and enums found in lib/models as PascalCase names
+func ProjectModels(projectDir string) ([]string, error) {
+	files, err := dartFileNames(filepath.Join(projectDir, "lib", "models"))
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]string, 0, len(files))
+	for _, file := range files {
+		models = append(models, ToPascalCase(file))
+	}
+	return models, nil
+}
+
+// ReadProjectModel reads the model or enum with the given name from lib/models, returning nil
+// when the project has none. Files staged by earlier generation steps are read as well.
+func ReadProjectModel(projectDir, name string) (*ProjectModel, error) {
+	file := filepath.Join(projectDir, "lib", "models", ToSnakeCase(name)+".dart")
+	if !FileExists(file) {
+		return nil, nil
+	}
+	content, err := ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	model := &ProjectModel{Name: ToPascalCase(name)}
+	name = regexp.QuoteMeta(model.Name)
+	if regexp.MustCompile(`(?m)^\s*enum\s+` + name + `\b`).Match(content) {
+		model.Enum = true
+		return model, nil
+	}
+
+	factory := regexp.MustCompile(`(?s)const\s+factory\s+` + name + `\(\{(.*?)\}\)`).FindSubmatch(content)
+	if factory == nil {
+		for _, match := range fieldDeclarationPattern.FindAllSubmatch(content, -1) {
+			model.Fields = append(model.Fields, Field{Name: string(match[2]), Type: string(match[1])})
+		}
+		return model, nil
+	}
+	for _, line := range strings.Split(string(factory[1]), "\n") {
+		if match := freezedParameterPattern.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			model.Fields = append(model.Fields, Field{Name: match[2], Type: match[1]})
+		}
+	}
+	return model, nil
+}
+
+// ProjectScreens returns the screens found in lib/screens as PascalCase names
+func ProjectScreens(projectDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(projectDir, "lib", "screens"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var screens []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			screens = append(screens, ToPascalCase(entry.Name()))
+		}
+	}
+	sort.Strings(screens)
+	return screens, nil
+}
+
+// ProjectRepositories returns the repositories found in lib/repositories, without the Repository suffix
+func ProjectRepositories(projectDir string) ([]string, error) {
+	files, err := dartFileNames(filepath.Join(projectDir, "lib", "repositories"))
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []string
+	for _, file := range files {
+		if name, ok := strings.CutSuffix(file, "_repository"); ok {
+			repositories = append(repositories, ToPascalCase(name))
+		}
+	}
+	return repositories, nil
+}
+
+// ProjectFieldTypes returns the built-in types, the models and the field types already used by models
+func ProjectFieldTypes(projectDir string) ([]string, error) {
+	models, err := ProjectModels(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var types []string
+	add := func(fieldType string) {
+		fieldType = strings.TrimSuffix(strings.TrimSpace(fieldType), "?")
+		if fieldType != "" && !seen[fieldType] {
+			seen[fieldType] = true
+			types = append(types, fieldType)
+		}
+	}
+
+	for _, fieldType := range BuiltinFieldTypes {
+		add(fieldType)
+	}
+	for _, model := range models {
+		add(model)
+	}
+
+	modelsDir := filepath.Join(projectDir, "lib", "models")
+	files, err := dartFileNames(modelsDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(modelsDir, file+".dart"))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range fieldDeclarationPattern.FindAllStringSubmatch(string(content), -1) {
+			add(match[1])
+		}
+	}
+
+	return types, nil
+}
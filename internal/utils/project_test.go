@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadProjectModel(t *testing.T) {
+	projectDir := t.TempDir()
+	modelsDir := filepath.Join(projectDir, "lib", "models")
+	if err := os.MkdirAll(modelsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"role.dart": "enum Role {\n  admin,\n  member,\n}\n",
+		"user.dart": "class User extends Equatable {\n  final String id;\n  final Map<String, int> scores;\n  final Role? role;\n\n" +
+			"  const User({required this.id, required this.scores, this.role});\n}\n",
+		"order.dart": "@freezed\nabstract class Order with _$Order {\n  const factory Order({\n    required String id,\n" +
+			"    @Default(1) int quantity,\n    required List<User> users,\n    String? note,\n  }) = _Order;\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(modelsDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		want *ProjectModel
+	}{
+		{"Role", &ProjectModel{Name: "Role", Enum: true}},
+		{"User", &ProjectModel{Name: "User", Fields: []Field{
+			{Name: "id", Type: "String"}, {Name: "scores", Type: "Map<String, int>"}, {Name: "role", Type: "Role?"},
+		}}},
+		{"Order", &ProjectModel{Name: "Order", Fields: []Field{
+			{Name: "id", Type: "String"}, {Name: "quantity", Type: "int"},
+			{Name: "users", Type: "List<User>"}, {Name: "note", Type: "String?"},
+		}}},
+		{"Product", nil},
+	}
+	for _, tt := range tests {
+		got, err := ReadProjectModel(projectDir, tt.name)
+		if err != nil {
+			t.Fatalf("ReadProjectModel(%s): %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ReadProjectModel(%s) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
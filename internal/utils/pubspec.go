@@ -2,8 +2,6 @@ package utils
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -17,6 +15,12 @@ const (
 	Dev     DependencyType = "dev_dependencies"
 )
 
+// dependencyBatch collects pub add arguments while a batch is open
+var dependencyBatch []string
+
+// batchingDependencies defers dependency additions until FlushDependencies
+var batchingDependencies bool
+
 type PubspecYaml struct {
 	Dependencies    map[string]interface{} `yaml:"dependencies"`
 	DevDependencies map[string]interface{} `yaml:"dev_dependencies"`
@@ -33,7 +37,7 @@ func AddDevDependency(dependency, projectDir string) error {
 func isDependencyExists(dependency, projectDir string, depType DependencyType) (bool, error) {
 	// Read pubspec.yaml
 	pubspecPath := filepath.Join(projectDir, "pubspec.yaml")
-	content, err := os.ReadFile(pubspecPath)
+	content, err := ReadFile(pubspecPath)
 	if err != nil {
 		return false, fmt.Errorf("failed to read pubspec.yaml: %w", err)
 	}
@@ -70,26 +74,127 @@ func addDependencyHelper(dependency, projectDir string, depType DependencyType)
 	}
 
 	// Prepare command arguments
-	args := []string{"pub", "add"}
+	arg := dependency
 	if depType == Dev {
-		args = append(args, fmt.Sprintf("dev:%s", dependency))
-	} else {
-		args = append(args, dependency)
+		arg = fmt.Sprintf("dev:%s", dependency)
 	}
 
-	// Execute Flutter pub add command
-	cmd := exec.Command("flutter", args...)
-	cmd.Dir = projectDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// Defer to a single pub add when batching
+	if batchingDependencies {
+		for _, pending := range dependencyBatch {
+			if pending == arg {
+				return nil
+			}
+		}
+		dependencyBatch = append(dependencyBatch, arg)
+		return nil
+	}
 
-	if err := cmd.Run(); err != nil {
+	// Execute Flutter pub add command
+	if err := runPubAdd(projectDir, arg); err != nil {
 		return fmt.Errorf("failed to add %s dependency %s: %w", depType, dependency, err)
 	}
 
 	return nil
 }
 
+// runPubAdd runs flutter pub add with the given packages
+func runPubAdd(projectDir string, packages ...string) error {
+	if DryRun() {
+		if err := planPubAdd(projectDir, packages); err != nil {
+			return err
+		}
+	}
+
+	// pub add rewrites both files, which a rollback restores
+	for _, file := range []string{"pubspec.yaml", "pubspec.lock"} {
+		if err := Track(filepath.Join(projectDir, file)); err != nil {
+			return err
+		}
+	}
+	return RunCommand(projectDir, "flutter", append([]string{"pub", "add"}, packages...)...)
+}
+
+// planPubAdd records the pubspec.yaml edit of flutter pub add during a dry run.
+// The version is resolved by pub, so the entry is shown as any.
+func planPubAdd(projectDir string, packages []string) error {
+	pubspecPath := filepath.Join(projectDir, "pubspec.yaml")
+	content, err := ReadFile(pubspecPath)
+	if err != nil {
+		return fmt.Errorf("failed to read pubspec.yaml: %w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for _, pkg := range packages {
+		section := string(Regular)
+		if name, ok := strings.CutPrefix(pkg, "dev:"); ok {
+			section, pkg = string(Dev), name
+		}
+		lines = addPubspecEntry(lines, section, "  "+pkg+": any")
+	}
+
+	return WriteFile(pubspecPath, []byte(strings.Join(lines, "\n")))
+}
+
+// addPubspecEntry inserts entry at the end of a top-level pubspec section, adding the section when missing
+func addPubspecEntry(lines []string, section, entry string) []string {
+	start := -1
+	for i, line := range lines {
+		if strings.TrimRight(line, " \r") == section+":" {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		return append(lines, "", section+":", entry, "")
+	}
+
+	// The section ends before the next top-level key, ignoring trailing blank lines and comments
+	end := start + 1
+	for i := start + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(lines[i], " ") && !strings.HasPrefix(lines[i], "\t") {
+			break
+		}
+		end = i + 1
+	}
+
+	return append(lines[:end], append([]string{entry}, lines[end:]...)...)
+}
+
+// BeginDependencyBatch defers dependency additions until FlushDependencies is called
+func BeginDependencyBatch() {
+	batchingDependencies = true
+	dependencyBatch = nil
+}
+
+// CancelDependencyBatch ends the batch without adding the deferred dependencies
+func CancelDependencyBatch() {
+	batchingDependencies = false
+	dependencyBatch = nil
+}
+
+// FlushDependencies adds every deferred dependency with a single flutter pub add and ends the batch
+func FlushDependencies(projectDir string) error {
+	packages := dependencyBatch
+	batchingDependencies = false
+	dependencyBatch = nil
+
+	if len(packages) == 0 {
+		return nil
+	}
+	if err := runPubAdd(projectDir, packages...); err != nil {
+		return fmt.Errorf("failed to add dependencies %s: %w", strings.Join(packages, ", "), err)
+	}
+	return nil
+}
+
 // AddFreezedDependencies adds Freezed-related dependencies to the project
 func AddFreezedDependencies(projectDir string) error {
 	// Add regular dependencies
@@ -117,7 +222,7 @@ func AddFreezedDependencies(projectDir string) error {
 func GetFlutterPackageName(projectDir string) (string, error) {
 	// Read pubspec.yaml
 	pubspecPath := filepath.Join(projectDir, "pubspec.yaml")
-	content, err := os.ReadFile(pubspecPath)
+	content, err := ReadFile(pubspecPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read pubspec.yaml: %w", err)
 	}
@@ -16,8 +16,9 @@ func ToSnakeCase(str string) string {
 }
 
 func ToPascalCase(str string) string {
-	// Split the string by non-alphanumeric characters
-	words := strings.FieldsFunc(str, func(r rune) bool {
+	// Split the snake cased string by non-alphanumeric characters so that the word
+	// boundaries of camel and Pascal case input are kept, e.g. getUserProfile -> GetUserProfile
+	words := strings.FieldsFunc(ToSnakeCase(str), func(r rune) bool {
 		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
 	})
 
@@ -30,3 +31,11 @@ func ToPascalCase(str string) string {
 
 	return strings.Join(words, "")
 }
+
+func ToCamelCase(str string) string {
+	pascal := ToPascalCase(str)
+	if pascal == "" {
+		return pascal
+	}
+	return strings.ToLower(pascal[0:1]) + pascal[1:]
+}
@@ -0,0 +1,51 @@
+package utils
+
+import "testing"
+
+func TestToPascalCase(t *testing.T) {
+	tests := map[string]string{
+		"user":           "User",
+		"user_profile":   "UserProfile",
+		"user-profile":   "UserProfile",
+		"UserProfile":    "UserProfile",
+		"getUserProfile": "GetUserProfile",
+		"HTTPClient":     "HttpClient",
+		"order2Item":     "Order2Item",
+	}
+	for input, want := range tests {
+		if got := ToPascalCase(input); got != want {
+			t.Errorf("ToPascalCase(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"user":           "user",
+		"user_profile":   "user_profile",
+		"UserProfile":    "user_profile",
+		"getUserProfile": "get_user_profile",
+		"HTTPClient":     "http_client",
+	}
+	for input, want := range tests {
+		if got := ToSnakeCase(input); got != want {
+			t.Errorf("ToSnakeCase(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestToCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"":               "",
+		"user":           "user",
+		"user_profile":   "userProfile",
+		"UserProfile":    "userProfile",
+		"firstName":      "firstName",
+		"get-user-by-id": "getUserById",
+	}
+	for input, want := range tests {
+		if got := ToCamelCase(input); got != want {
+			t.Errorf("ToCamelCase(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package utils
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// StdinIsTerminal reports whether stdin is attached to a terminal, so that prompts can be answered
+func StdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
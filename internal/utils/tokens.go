@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ColorToken is a named color in ARGB form
+type ColorToken struct {
+	Name string
+	ARGB uint32
+}
+
+// TextToken is a named text style
+type TextToken struct {
+	Name          string
+	FontFamily    string
+	FontSize      *float64
+	FontWeight    *int
+	Height        *float64
+	LetterSpacing *float64
+}
+
+// NumberToken is a named numeric value such as a spacing step
+type NumberToken struct {
+	Name  string
+	Value float64
+}
+
+// DesignTokens holds the tokens exported by designers, sorted by name
+type DesignTokens struct {
+	LightColors []ColorToken
+	DarkColors  []ColorToken
+	Typography  []TextToken
+	Spacing     []NumberToken
+	// SharedColors is set when the same colors are used for light and dark modes
+	SharedColors bool
+}
+
+type designTokensFile struct {
+	Colors     map[string]json.RawMessage `json:"colors"`
+	Typography map[string]struct {
+		FontFamily    string   `json:"fontFamily"`
+		FontSize      *float64 `json:"fontSize"`
+		FontWeight    *int     `json:"fontWeight"`
+		Height        *float64 `json:"height"`
+		LetterSpacing *float64 `json:"letterSpacing"`
+	} `json:"typography"`
+	Spacing map[string]float64 `json:"spacing"`
+}
+
+// ParseDesignTokens reads a design token JSON file.
+// Colors are either a flat map shared by both modes or split into light and dark maps.
+func ParseDesignTokens(file string) (*DesignTokens, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	var raw designTokensFile
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	tokens := &DesignTokens{}
+
+	_, hasLight := raw.Colors["light"]
+	_, hasDark := raw.Colors["dark"]
+	if hasLight || hasDark {
+		if tokens.LightColors, err = parseColorGroup(raw.Colors["light"]); err != nil {
+			return nil, fmt.Errorf("invalid light colors: %w", err)
+		}
+		if tokens.DarkColors, err = parseColorGroup(raw.Colors["dark"]); err != nil {
+			return nil, fmt.Errorf("invalid dark colors: %w", err)
+		}
+	} else {
+		if tokens.LightColors, err = parseColors(raw.Colors); err != nil {
+			return nil, err
+		}
+		tokens.DarkColors = tokens.LightColors
+		tokens.SharedColors = true
+	}
+
+	for name, style := range raw.Typography {
+		if style.FontWeight != nil && (*style.FontWeight < 100 || *style.FontWeight > 900 || *style.FontWeight%100 != 0) {
+			return nil, fmt.Errorf("invalid fontWeight %d for %s, expected 100 to 900", *style.FontWeight, name)
+		}
+		tokens.Typography = append(tokens.Typography, TextToken{
+			Name:          name,
+			FontFamily:    style.FontFamily,
+			FontSize:      style.FontSize,
+			FontWeight:    style.FontWeight,
+			Height:        style.Height,
+			LetterSpacing: style.LetterSpacing,
+		})
+	}
+	sort.Slice(tokens.Typography, func(i, j int) bool { return tokens.Typography[i].Name < tokens.Typography[j].Name })
+
+	for name, value := range raw.Spacing {
+		tokens.Spacing = append(tokens.Spacing, NumberToken{Name: name, Value: value})
+	}
+	sort.Slice(tokens.Spacing, func(i, j int) bool { return tokens.Spacing[i].Name < tokens.Spacing[j].Name })
+
+	return tokens, nil
+}
+
+// parseColorGroup parses a JSON object of colors, allowing it to be absent
+func parseColorGroup(data json.RawMessage) ([]ColorToken, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var group map[string]json.RawMessage
+	if err := json.Unmarshal(data, &group); err != nil {
+		return nil, err
+	}
+	return parseColors(group)
+}
+
+// parseColors converts hex color strings into sorted color tokens
+func parseColors(group map[string]json.RawMessage) ([]ColorToken, error) {
+	var colors []ColorToken
+	for name, data := range group {
+		var hex string
+		if err := json.Unmarshal(data, &hex); err != nil {
+			return nil, fmt.Errorf("color %s must be a hex string", name)
+		}
+
+		argb, err := ParseHexColor(hex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color %s: %w", name, err)
+		}
+		colors = append(colors, ColorToken{Name: name, ARGB: argb})
+	}
+
+	sort.Slice(colors, func(i, j int) bool { return colors[i].Name < colors[j].Name })
+	return colors, nil
+}
+
+// ParseHexColor parses #RRGGBB or #AARRGGBB into an ARGB value
+func ParseHexColor(hex string) (uint32, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	switch len(digits) {
+	case 6:
+		digits = "FF" + digits
+	case 8:
+	default:
+		return 0, fmt.Errorf("%q is not in #RRGGBB or #AARRGGBB form", hex)
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a hex color", hex)
+	}
+	return uint32(value), nil
+}
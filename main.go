@@ -1,13 +1,17 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"flart/internal/commands"
 	"flart/internal/config"
+	"flart/internal/utils"
 	"flart/internal/version"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/AlecAivazis/survey/v2"
 )
@@ -15,64 +19,63 @@ import (
 const (
 	cmdNewScreen     = "New Screen"
 	cmdNewModel      = "New Model"
+	cmdNewUseCase    = "New Use Case"
+	cmdNewWidget     = "New Widget"
+	cmdNewDataSource = "New Data Source"
 	cmdBuildRunner   = "Build Runner"
 	cmdWatchRunner   = "Watch Runner"
 	cmdMakeModel     = "make:model"
 	cmdMakeScreen    = "make:screen"
+	cmdMakeUseCase   = "make:usecase"
+	cmdMakeWidget    = "make:widget"
+	cmdMakeDataSrc   = "make:datasource"
+	cmdMakeFlavors   = "make:flavors"
+	cmdDestroyScreen = "destroy:screen"
+	cmdDestroyModel  = "destroy:model"
+	cmdRenameScreen  = "rename:screen"
+	cmdRenameModel   = "rename:model"
+	cmdL10nAdd       = "l10n:add"
+	cmdL10nCheck     = "l10n:check"
+	cmdGenAssets     = "gen:assets"
+	cmdGenTheme      = "gen:theme"
+	cmdApply         = "apply"
+	cmdPackInstall   = "pack:install"
+	cmdPackList      = "pack:list"
+	cmdUndo          = "undo"
+	cmdHistory       = "history"
 	cmdBuildRunnerCL = "build:runner"
 	cmdWatchRunnerCL = "watch:runner"
+	cmdCompletion    = "completion"
+	cmdComplete      = "__complete"
 )
 
 func main() {
-	// Define version flag
-	versionFlag := flag.Bool("v", false, "Show version information")
-	versionLongFlag := flag.Bool("version", false, "Show version information")
-
-	// Parse flags before other logic
-	flag.Parse()
+	os.Exit(execute(os.Args[1:]))
+}
 
-	// Check version flags first
-	if *versionFlag || *versionLongFlag {
-		fmt.Printf("Flart version %s\n", version.Version)
-		os.Exit(0)
+// execute runs the CLI and maps the outcome to an exit code
+func execute(args []string) int {
+	err := run(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return exitOK
 	}
 
-	// Get remaining arguments after flag parsing
-	args := flag.Args()
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 
-	if err := run(args); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		if usageErr.command != "" {
+			fmt.Fprintf(os.Stderr, "Run 'flart %s --help' for usage.\n", usageErr.command)
+		} else {
+			fmt.Fprintln(os.Stderr, "Run 'flart --help' for usage.")
+		}
+		return exitUsage
 	}
+	return exitFailure
 }
 
-func run(args []string) error {
-	switch len(args) {
-	case 2:
-		return handleMakeCommand(args[0], args[1])
-	case 1:
-		return handleBuildCommand(args[0])
-	case 0:
-		return handleInteractive()
-	default:
-		return fmt.Errorf("too many arguments")
-	}
-}
-
-func handleBuildCommand(command string) error {
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	switch command {
-	case cmdBuildRunnerCL:
-		return runBuildRunner(*cfg.ProjectDir, false)
-	case cmdWatchRunnerCL:
-		return runBuildRunner(*cfg.ProjectDir, true)
-	default:
-		return fmt.Errorf("unknown command: %s", command)
-	}
+func printVersion() {
+	fmt.Printf("Flart version %s\n", version.Version)
 }
 
 func runBuildRunner(projectDir string, watch bool) error {
@@ -100,34 +103,493 @@ func runBuildRunner(projectDir string, watch bool) error {
 	return nil
 }
 
-func handleMakeCommand(command, name string) error {
-	switch command {
-	case cmdMakeModel:
-		if err := commands.CreateModel(name); err != nil {
-			return fmt.Errorf("failed to create model: %w", err)
+// createAll creates each named artifact, printing a summary table when several names are given
+func createAll(kind string, names []string, create func(name string) error) error {
+	if len(names) == 1 {
+		err := commands.Transaction(func() error { return create(names[0]) })
+		if errors.Is(err, commands.ErrKept) {
+			fmt.Printf("%s %s skipped: %v\n", kind, names[0], err)
+			return nil
 		}
-		fmt.Printf("Model %s created successfully!\n", name)
-
-	case cmdMakeScreen:
-		if err := commands.CreateScreen(name); err != nil {
-			return fmt.Errorf("failed to create screen: %w", err)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", strings.ToLower(kind), err)
 		}
-		fmt.Printf("Screen %s created successfully!\n", name)
+		printSuccess("%s %s created successfully!\n", kind, names[0])
+		return nil
+	}
+
+	results, err := commands.CreateBatch(names, create)
+	if err != nil {
+		printBatchSummary(kind, results)
+		return err
+	}
 
-	default:
-		return fmt.Errorf("unknown command: %s", command)
+	failed := 0
+	for _, result := range results {
+		if result.Status == commands.StatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 || !utils.DryRun() {
+		printBatchSummary(kind, results)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d %ss failed", failed, len(results), strings.ToLower(kind))
 	}
 	return nil
 }
 
+// printSuccess prints a success message, which a dry run replaces with its plan
+func printSuccess(format string, args ...any) {
+	if !utils.DryRun() {
+		fmt.Printf(format, args...)
+	}
+}
+
+// printBatchSummary prints what was created, skipped or failed in a batch
+func printBatchSummary(kind string, results []commands.BatchResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "\n%s\tSTATUS\tDETAILS\n", strings.ToUpper(kind))
+	for _, result := range results {
+		details := ""
+		if result.Status == commands.StatusFailed && result.Err != nil {
+			details = result.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Name, result.Status, details)
+	}
+	w.Flush()
+}
+
+// splitList splits a comma separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func handleMakeModel(fs *flag.FlagSet) commandFunc {
+	fieldSpec := fs.String("fields", "", "Comma separated name:Type fields, defaults to an id field")
+	defineOverrideFlag(fs, "freezed", "Use Freezed, overrides models.useFreezed", func(value *bool) config.Overrides {
+		return config.Overrides{ModelsUseFreezed: value}
+	})
+
+	return func(args []string) error {
+		names, err := parseArgs(fs, args, 1, -1)
+		if err != nil {
+			return err
+		}
+
+		fields, err := utils.ParseFields(*fieldSpec)
+		if err != nil {
+			return err
+		}
+
+		return createAll("Model", names, func(name string) error {
+			return commands.CreateModel(name, commands.ModelOptions{Fields: fields})
+		})
+	}
+}
+
+func handleMakeScreen(fs *flag.FlagSet) commandFunc {
+	form := fs.String("form", "", "Generate a form with the given name:Type@validator fields")
+	list := fs.String("list", "", "Generate a paginated list of the given model")
+	tabs := fs.String("tabs", "", "Generate a tabbed shell with one comma separated screen per tab")
+	topTabs := fs.Bool("top-tabs", false, "Use a TabBar instead of a BottomNavigationBar")
+	defineOverrideFlag(fs, "freezed", "Use Freezed for states and events, overrides screens.useFreezed", func(value *bool) config.Overrides {
+		return config.Overrides{ScreensUseFreezed: value}
+	})
+	defineOverrideFlag(fs, "cubit", "Use a Cubit instead of a Bloc, overrides screens.useCubit", func(value *bool) config.Overrides {
+		return config.Overrides{ScreensUseCubit: value}
+	})
+
+	return func(args []string) error {
+		positional, err := parseFlags(fs, args)
+		if err != nil {
+			return err
+		}
+
+		// Arguments holding a colon are form field specs, the others are screen names
+		var names, extra []string
+		for _, arg := range positional {
+			if strings.Contains(arg, ":") {
+				extra = append(extra, arg)
+			} else {
+				names = append(names, arg)
+			}
+		}
+		if len(names) == 0 {
+			return usageErrorf(cmdMakeScreen, "%s: missing arguments", cmdMakeScreen)
+		}
+		if *form == "" && len(extra) > 0 {
+			return usageErrorf(cmdMakeScreen, "%s: unexpected arguments: %s", cmdMakeScreen, strings.Join(extra, " "))
+		}
+
+		// Form fields may be comma or space separated
+		fields, err := utils.ParseFields(strings.Join(append([]string{*form}, extra...), ","))
+		if err != nil {
+			return err
+		}
+
+		opts := commands.ScreenOptions{
+			Form:    fields,
+			List:    *list,
+			Tabs:    splitList(*tabs),
+			TopTabs: *topTabs,
+		}
+		return createAll("Screen", names, func(name string) error {
+			return commands.CreateScreen(name, opts)
+		})
+	}
+}
+
+// handleMakeCustom returns the setup of make:<generator> for a generator declared in the config
+func handleMakeCustom(generator string) func(fs *flag.FlagSet) commandFunc {
+	return func(fs *flag.FlagSet) commandFunc {
+		fieldSpec := fs.String("fields", "", "Comma separated name:Type fields passed to the templates")
+
+		return func(args []string) error {
+			names, err := parseArgs(fs, args, 1, -1)
+			if err != nil {
+				return err
+			}
+
+			fields, err := utils.ParseFields(*fieldSpec)
+			if err != nil {
+				return err
+			}
+
+			return createAll(customKind(generator), names, func(name string) error {
+				return commands.CreateCustom(generator, name, fields)
+			})
+		}
+	}
+}
+
+// customKind turns a generator name into the artifact kind shown in messages, e.g. api_client -> Api client
+func customKind(generator string) string {
+	kind := strings.ReplaceAll(generator, "_", " ")
+	return strings.ToUpper(kind[:1]) + kind[1:]
+}
+
+func handleMakeUseCase(fs *flag.FlagSet) commandFunc {
+	repo := fs.String("repo", "", "Repository the use case depends on")
+	returns := fs.String("returns", "", "Return type of call()")
+	params := fs.String("params", "", "Comma separated name:Type params")
+	defineOverrideFlag(fs, "freezed", "Use Freezed for the params class, overrides models.useFreezed", func(value *bool) config.Overrides {
+		return config.Overrides{ModelsUseFreezed: value}
+	})
+
+	return func(args []string) error {
+		names, err := parseArgs(fs, args, 1, -1)
+		if err != nil {
+			return err
+		}
+
+		fields, err := utils.ParseFields(*params)
+		if err != nil {
+			return err
+		}
+
+		opts := commands.UseCaseOptions{Repo: *repo, Returns: *returns, Params: fields}
+		return createAll("Use case", names, func(name string) error {
+			return commands.CreateUseCase(name, opts)
+		})
+	}
+}
+
+func handleMakeWidget(fs *flag.FlagSet) commandFunc {
+	stateful := fs.Bool("stateful", false, "Generate a StatefulWidget")
+	golden := fs.Bool("golden", false, "Also generate a golden test scaffold")
+	params := fs.String("params", "", "Comma separated name:Type constructor params")
+
+	return func(args []string) error {
+		names, err := parseArgs(fs, args, 1, -1)
+		if err != nil {
+			return err
+		}
+
+		fields, err := utils.ParseFields(*params)
+		if err != nil {
+			return err
+		}
+
+		opts := commands.WidgetOptions{Stateful: *stateful, Golden: *golden, Params: fields}
+		return createAll("Widget", names, func(name string) error {
+			return commands.CreateWidget(name, opts)
+		})
+	}
+}
+
+func handleMakeDataSource(fs *flag.FlagSet) commandFunc {
+	remote := fs.Bool("remote", false, "Generate a remote data source using Dio")
+	local := fs.Bool("local", false, "Generate a local data source using the configured storage")
+
+	return func(args []string) error {
+		names, err := parseArgs(fs, args, 1, -1)
+		if err != nil {
+			return err
+		}
+
+		opts := commands.DataSourceOptions{Remote: *remote, Local: *local}
+		return createAll("Data source", names, func(name string) error {
+			return commands.CreateDataSource(name, opts)
+		})
+	}
+}
+
+func handleGenAssets(fs *flag.FlagSet) commandFunc {
+	watch := fs.Bool("watch", false, "Regenerate the constants whenever assets change")
+
+	return func(args []string) error {
+		if _, err := parseArgs(fs, args, 0, 0); err != nil {
+			return err
+		}
+		if *watch && utils.DryRun() {
+			return usageErrorf(cmdGenAssets, "%s: --watch cannot be combined with --dry-run", cmdGenAssets)
+		}
+
+		return commands.GenerateAssets(*watch)
+	}
+}
+
+func handleGenTheme(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		positional, err := parseArgs(fs, args, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		return commands.GenerateTheme(positional[0])
+	}
+}
+
+func handleMakeFlavors(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		positional, err := parseArgs(fs, args, 0, 1)
+		if err != nil {
+			return err
+		}
+
+		var flavors []string
+		if len(positional) == 1 {
+			flavors = splitList(positional[0])
+		}
+		if err := commands.CreateFlavors(flavors); err != nil {
+			return fmt.Errorf("failed to create flavors: %w", err)
+		}
+		printSuccess("Flavors created successfully!\n")
+		return nil
+	}
+}
+
+func handleApply(fs *flag.FlagSet) commandFunc {
+	defineOverrideFlag(fs, "freezed", "Use Freezed for models and screens, overrides the config", func(value *bool) config.Overrides {
+		return config.Overrides{ModelsUseFreezed: value, ScreensUseFreezed: value}
+	})
+	defineOverrideFlag(fs, "cubit", "Use Cubits for screens, overrides screens.useCubit", func(value *bool) config.Overrides {
+		return config.Overrides{ScreensUseCubit: value}
+	})
+
+	return func(args []string) error {
+		positional, err := parseArgs(fs, args, 0, 1)
+		if err != nil {
+			return err
+		}
+
+		manifest := "flart.yaml"
+		if len(positional) == 1 {
+			manifest = positional[0]
+		}
+		if err := commands.Apply(manifest); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", manifest, err)
+		}
+		printSuccess("Applied %s successfully!\n", manifest)
+		return nil
+	}
+}
+
+func handleL10nAdd(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		positional, err := parseArgs(fs, args, 2, 2)
+		if err != nil {
+			return err
+		}
+
+		return commands.AddL10nString(positional[0], positional[1])
+	}
+}
+
+func handleL10nCheck(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		if _, err := parseArgs(fs, args, 0, 0); err != nil {
+			return err
+		}
+
+		return commands.CheckL10n()
+	}
+}
+
+func handleDestroyScreen(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		names, err := parseArgs(fs, args, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		if err := commands.DestroyScreen(names[0]); err != nil {
+			return fmt.Errorf("failed to destroy screen: %w", err)
+		}
+		printSuccess("Screen %s destroyed\n", names[0])
+		return nil
+	}
+}
+
+func handleDestroyModel(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		names, err := parseArgs(fs, args, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		if err := commands.DestroyModel(names[0]); err != nil {
+			return fmt.Errorf("failed to destroy model: %w", err)
+		}
+		printSuccess("Model %s destroyed\n", names[0])
+		return nil
+	}
+}
+
+func handleRenameScreen(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		names, err := parseArgs(fs, args, 2, 2)
+		if err != nil {
+			return err
+		}
+
+		if err := commands.RenameScreen(names[0], names[1]); err != nil {
+			return fmt.Errorf("failed to rename screen: %w", err)
+		}
+		printSuccess("Screen %s renamed to %s\n", names[0], names[1])
+		return nil
+	}
+}
+
+func handleRenameModel(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		names, err := parseArgs(fs, args, 2, 2)
+		if err != nil {
+			return err
+		}
+
+		if err := commands.RenameModel(names[0], names[1]); err != nil {
+			return fmt.Errorf("failed to rename model: %w", err)
+		}
+		printSuccess("Model %s renamed to %s\n", names[0], names[1])
+		return nil
+	}
+}
+
+func handlePackInstall(fs *flag.FlagSet) commandFunc {
+	use := fs.Bool("use", false, "Select the pack as templatePack in the config")
+
+	return func(args []string) error {
+		positional, err := parseArgs(fs, args, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		pack, err := commands.InstallPack(positional[0], *use)
+		if err != nil {
+			return fmt.Errorf("failed to install pack: %w", err)
+		}
+		printSuccess("Pack %s installed successfully!\n", pack.Name)
+		return nil
+	}
+}
+
+func handlePackList(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		if _, err := parseArgs(fs, args, 0, 0); err != nil {
+			return err
+		}
+
+		return commands.ListPacks()
+	}
+}
+
+func handleUndo(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		if _, err := parseArgs(fs, args, 0, 0); err != nil {
+			return err
+		}
+
+		// --force and --yes also undo files edited since the generation
+		return commands.Undo(commands.CurrentOverwritePolicy() == commands.OverwriteAlways)
+	}
+}
+
+func handleHistory(fs *flag.FlagSet) commandFunc {
+	return func(args []string) error {
+		if _, err := parseArgs(fs, args, 0, 0); err != nil {
+			return err
+		}
+
+		return commands.History()
+	}
+}
+
+func handleBuildRunner(fs *flag.FlagSet) commandFunc {
+	return handleRunner(fs, false)
+}
+
+func handleWatchRunner(fs *flag.FlagSet) commandFunc {
+	return handleRunner(fs, true)
+}
+
+func handleRunner(fs *flag.FlagSet, watch bool) commandFunc {
+	return func(args []string) error {
+		if _, err := parseArgs(fs, args, 0, 0); err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		return runBuildRunner(*cfg.ProjectDir, watch)
+	}
+}
+
 func handleInteractive() error {
+	if !commands.Interactive() {
+		return usageErrorf("", "interactive mode needs a terminal, run a command such as 'flart make:model <Name>' instead")
+	}
+
 	options := []string{
 		cmdNewScreen,
 		cmdNewModel,
-		cmdBuildRunner,
-		cmdWatchRunner,
+		cmdNewUseCase,
+		cmdNewWidget,
+		cmdNewDataSource,
 	}
 
+	// Custom generators from the config are offered after the built-in artifacts
+	custom := map[string]string{}
+	generators, err := commands.CustomGenerators()
+	if err != nil {
+		return err
+	}
+	for _, generator := range generators {
+		option := "New " + customKind(generator.Name)
+		custom[option] = generator.Name
+		options = append(options, option)
+	}
+	options = append(options, cmdBuildRunner, cmdWatchRunner)
+
 	var choice string
 	prompt := &survey.Select{
 		Message: "Choose an option:",
@@ -138,12 +600,27 @@ func handleInteractive() error {
 		return fmt.Errorf("failed to get user choice: %w", err)
 	}
 
+	if generator, ok := custom[choice]; ok {
+		return handleNamePrompt(strings.ToLower(customKind(generator)), func(name string) error {
+			return createCustomInteractive(generator, name)
+		})
+	}
+
 	switch choice {
 	case cmdNewScreen:
-		return handleNamePrompt("screen", commands.CreateScreen)
+		return handleNamePrompt("screen", createScreenInteractive)
 
 	case cmdNewModel:
-		return handleNamePrompt("model", commands.CreateModel)
+		return handleNamePrompt("model", createModelInteractive)
+
+	case cmdNewUseCase:
+		return handleNamePrompt("use case", createUseCaseInteractive)
+
+	case cmdNewWidget:
+		return handleNamePrompt("widget", createWidgetInteractive)
+
+	case cmdNewDataSource:
+		return handleNamePrompt("data source", createDataSourceInteractive)
 
 	case cmdBuildRunner:
 		cfg, err := config.Load()
@@ -163,6 +640,164 @@ func handleInteractive() error {
 	return nil
 }
 
+func createScreenInteractive(name string) error {
+	var kind string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Choose a screen type:",
+		Options: []string{"Basic", "Form", "List", "Tabs"},
+	}, &kind); err != nil {
+		return fmt.Errorf("failed to get screen type: %w", err)
+	}
+
+	var opts commands.ScreenOptions
+	switch kind {
+	case "Form":
+		var form string
+		if err := survey.AskOne(&survey.Input{
+			Message: "Enter form fields (name:Type@validator, comma separated):",
+		}, &form, survey.WithValidator(survey.Required)); err != nil {
+			return fmt.Errorf("failed to get form fields: %w", err)
+		}
+
+		fields, err := utils.ParseFields(form)
+		if err != nil {
+			return err
+		}
+		opts.Form = fields
+
+	case "List":
+		if err := survey.AskOne(&survey.Input{
+			Message: "Enter the list item model:",
+		}, &opts.List, survey.WithValidator(survey.Required)); err != nil {
+			return fmt.Errorf("failed to get list item model: %w", err)
+		}
+
+	case "Tabs":
+		var tabs string
+		if err := survey.AskOne(&survey.Input{
+			Message: "Enter tab screen names (comma separated):",
+		}, &tabs, survey.WithValidator(survey.Required)); err != nil {
+			return fmt.Errorf("failed to get tabs: %w", err)
+		}
+		opts.Tabs = splitList(tabs)
+
+		if err := survey.AskOne(&survey.Confirm{
+			Message: "Use a TabBar instead of a BottomNavigationBar?",
+		}, &opts.TopTabs); err != nil {
+			return fmt.Errorf("failed to get tab style: %w", err)
+		}
+	}
+
+	return commands.CreateScreen(name, opts)
+}
+
+func createModelInteractive(name string) error {
+	var spec string
+	prompt := &survey.Input{Message: "Enter fields (name:Type, comma separated, empty for an id field):"}
+	if err := survey.AskOne(prompt, &spec); err != nil {
+		return fmt.Errorf("failed to get model fields: %w", err)
+	}
+
+	fields, err := utils.ParseFields(spec)
+	if err != nil {
+		return err
+	}
+
+	return commands.CreateModel(name, commands.ModelOptions{Fields: fields})
+}
+
+func createUseCaseInteractive(name string) error {
+	answers := struct {
+		Repo    string
+		Returns string
+		Params  string
+	}{}
+	questions := []*survey.Question{
+		{Name: "repo", Prompt: &survey.Input{Message: "Enter repository name:"}, Validate: survey.Required},
+		{Name: "returns", Prompt: &survey.Input{Message: "Enter return type (empty for void):"}},
+		{Name: "params", Prompt: &survey.Input{Message: "Enter params (name:Type, comma separated):"}},
+	}
+	if err := survey.Ask(questions, &answers); err != nil {
+		return fmt.Errorf("failed to get use case options: %w", err)
+	}
+
+	fields, err := utils.ParseFields(answers.Params)
+	if err != nil {
+		return err
+	}
+
+	return commands.CreateUseCase(name, commands.UseCaseOptions{
+		Repo:    answers.Repo,
+		Returns: answers.Returns,
+		Params:  fields,
+	})
+}
+
+func createWidgetInteractive(name string) error {
+	answers := struct {
+		Stateful bool
+		Golden   bool
+		Params   string
+	}{}
+	questions := []*survey.Question{
+		{Name: "stateful", Prompt: &survey.Confirm{Message: "Make it a StatefulWidget?"}},
+		{Name: "golden", Prompt: &survey.Confirm{Message: "Generate a golden test?"}},
+		{Name: "params", Prompt: &survey.Input{Message: "Enter constructor params (name:Type, comma separated):"}},
+	}
+	if err := survey.Ask(questions, &answers); err != nil {
+		return fmt.Errorf("failed to get widget options: %w", err)
+	}
+
+	fields, err := utils.ParseFields(answers.Params)
+	if err != nil {
+		return err
+	}
+
+	return commands.CreateWidget(name, commands.WidgetOptions{
+		Stateful: answers.Stateful,
+		Golden:   answers.Golden,
+		Params:   fields,
+	})
+}
+
+func createCustomInteractive(generator, name string) error {
+	var spec string
+	prompt := &survey.Input{Message: "Enter fields (name:Type, comma separated, empty for none):"}
+	if err := survey.AskOne(prompt, &spec); err != nil {
+		return fmt.Errorf("failed to get fields: %w", err)
+	}
+
+	fields, err := utils.ParseFields(spec)
+	if err != nil {
+		return err
+	}
+
+	return commands.CreateCustom(generator, name, fields)
+}
+
+func createDataSourceInteractive(name string) error {
+	var variants []string
+	if err := survey.AskOne(&survey.MultiSelect{
+		Message: "Choose data source variants:",
+		Options: []string{"remote", "local"},
+		Default: []string{"remote", "local"},
+	}, &variants); err != nil {
+		return fmt.Errorf("failed to get data source variants: %w", err)
+	}
+
+	var opts commands.DataSourceOptions
+	for _, variant := range variants {
+		switch variant {
+		case "remote":
+			opts.Remote = true
+		case "local":
+			opts.Local = true
+		}
+	}
+
+	return commands.CreateDataSource(name, opts)
+}
+
 func handleNamePrompt(itemType string, createFn func(string) error) error {
 	var name string
 	if err := survey.AskOne(&survey.Input{
@@ -171,7 +806,12 @@ func handleNamePrompt(itemType string, createFn func(string) error) error {
 		return fmt.Errorf("failed to get %s name: %w", itemType, err)
 	}
 
-	if err := createFn(name); err != nil {
+	err := commands.Transaction(func() error { return createFn(name) })
+	if errors.Is(err, commands.ErrKept) {
+		fmt.Printf("%s %s skipped: %v\n", itemType, name, err)
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("failed to create %s: %w", itemType, err)
 	}
 	fmt.Printf("%s %s created successfully!\n", itemType, name)